@@ -0,0 +1,324 @@
+package oracle
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrColumnNarrowed is returned by AlterColumn when the column can not be narrowed with the
+// existing data, e.g.: the longer values of VARCHAR2(100) altered to VARCHAR2(50) raise ORA-01441,
+// and the precision or scale of NUMBER can be decreased only when the column is empty, ORA-01440.
+var ErrColumnNarrowed = errors.New("column can not be narrowed with the existing data")
+
+// dataTypePattern matches the sized data types, e.g.: VARCHAR2(100 CHAR), NUMBER(10, 2), NUMBER(*,0)
+var dataTypePattern = regexp.MustCompile(`^([A-Z][A-Z0-9_]*)\s*\(\s*(\d+|\*)\s*(?:,\s*(-?\d+)\s*)?(?:\s(CHAR|BYTE)\s*)?\)$`)
+
+// FullDataTypeOf returns the data type of the field with the default value and the constraints,
+// in the order required by Oracle: type [DEFAULT expr] [NULL|NOT NULL] [UNIQUE]. The default value
+// is rendered as a literal, since the bind variables are not allowed in DDL.
+func (m Migrator) FullDataTypeOf(field *schema.Field) (expr clause.Expr) {
+	dataType, nullability := splitNullability(m.Migrator.DataTypeOf(field))
+	expr.SQL = dataType
+	if value, ok := m.defaultValueOf(field); ok {
+		expr.SQL += " DEFAULT " + value
+	}
+	if field.NotNull {
+		nullability = "NOT NULL"
+	}
+	if nullability != "" {
+		expr.SQL += " " + nullability
+	}
+	if field.Unique {
+		expr.SQL += " UNIQUE"
+	}
+	return
+}
+
+// AlterColumn alters the column to the field by ALTER TABLE t MODIFY (c type [DEFAULT expr] [NULL|NOT NULL]).
+// The column is compared with ColumnTypes, and only the changed parts are restated, since restating
+// the nullability raises ORA-01442 and ORA-01451.
+//
+// The conversions between LOB and the other types, e.g.: VARCHAR2 to CLOB, which are not supported
+// by MODIFY, are done by adding a new column, copying the values, dropping the old column and renaming
+// the new one, the column is moved to the end of the table, and its indexes and constraints are dropped.
+//
+// ErrColumnNarrowed is returned if the length, precision or scale is decreased and the existing data
+// do not fit, nothing is altered.
+func (m Migrator) AlterColumn(value interface{}, field string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		f := stmt.Schema.LookUpField(field)
+		if f == nil {
+			return fmt.Errorf("failed to look up field with name: %s", field)
+		}
+
+		current, err := m.columnTypeOf(value, f.DBName)
+		if err != nil {
+			return err
+		}
+
+		var (
+			parts            []string
+			currentType, _   = current.ColumnType()
+			from             = parseDataType(currentType)
+			to               = parseDataType(m.alterDataType(f))
+			autoIncrement, _ = current.AutoIncrement()
+			nullable, _      = current.Nullable()
+			primaryKey, _    = current.PrimaryKey()
+			notNull          = f.NotNull || f.PrimaryKey
+			defaultValue, _  = m.defaultValueOf(f)
+		)
+
+		if !from.equal(to) {
+			if from.isLOB() != to.isLOB() {
+				return m.convertColumn(stmt, f, from, to)
+			}
+			if err = m.checkNarrowing(stmt, f, from, to); err != nil {
+				return err
+			}
+			parts = append(parts, to.text)
+		}
+
+		// the default value of identity column is generated by Oracle
+		if !f.PrimaryKey && !autoIncrement {
+			currentDefault, _ := current.DefaultValue()
+			if normalizeDefault(sql.NullString{String: defaultValue, Valid: true}).String != currentDefault {
+				if defaultValue == "" {
+					defaultValue = "NULL"
+				}
+				parts = append(parts, "DEFAULT "+defaultValue)
+			}
+		}
+
+		switch {
+		case notNull && nullable:
+			parts = append(parts, "NOT NULL")
+		case !notNull && !nullable && !primaryKey:
+			parts = append(parts, "NULL")
+		}
+
+		if len(parts) == 0 {
+			return nil
+		}
+		return m.DB.Exec(
+			"ALTER TABLE ? MODIFY (? ?)",
+			clause.Table{Name: stmt.Table}, clause.Column{Name: f.DBName}, clause.Expr{SQL: strings.Join(parts, " ")},
+		).Error
+	})
+}
+
+// columnTypeOf returns the column of the table read by ColumnTypes
+func (m Migrator) columnTypeOf(value interface{}, name string) (gorm.ColumnType, error) {
+	columnTypes, err := m.ColumnTypes(value)
+	if err != nil {
+		return nil, err
+	}
+	for _, columnType := range columnTypes {
+		if columnType.Name() == dictionaryName(name) {
+			return columnType, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to find column %s", name)
+}
+
+// convertColumn converts the column between LOB and the other types by add-copy-drop-rename,
+// the temporary column is dropped if the values are not moved to it, and is named in the error
+// if it can not be renamed, since the values are kept in it.
+func (m Migrator) convertColumn(stmt *gorm.Statement, field *schema.Field, from, to dataType) error {
+	if err := m.checkNarrowing(stmt, field, from, to); err != nil {
+		return err
+	}
+
+	var (
+		table      = clause.Table{Name: stmt.Table}
+		column     = clause.Column{Name: field.DBName}
+		tempColumn = clause.Column{Name: tempColumnName(field.DBName, m.IdentifierMaxLength())}
+		definition = to.text
+	)
+	if value, ok := m.defaultValueOf(field); ok {
+		definition += " DEFAULT " + value
+	}
+
+	// the hidden and unused columns are included, whose names can not be reused either
+	var count int64
+	owner, tableName := m.CurrentSchema(stmt, stmt.Table)
+	if err := m.DB.Raw(
+		"SELECT COUNT(*) FROM ALL_TAB_COLS WHERE OWNER = ? AND TABLE_NAME = ? AND COLUMN_NAME = ?",
+		owner, tableName, dictionaryName(tempColumn.Name),
+	).Row().Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("failed to convert column %s of %s, the temporary column %s already exists", field.DBName, stmt.Table, tempColumn.Name)
+	}
+
+	if err := m.DB.Exec("ALTER TABLE ? ADD (? ?)", table, tempColumn, clause.Expr{SQL: definition}).Error; err != nil {
+		return err
+	}
+	if err := m.DB.Exec("UPDATE ? SET ? = ?", table, tempColumn, column).Error; err != nil {
+		m.DB.Exec("ALTER TABLE ? DROP COLUMN ?", table, tempColumn)
+		return err
+	}
+	if err := m.DB.Exec("ALTER TABLE ? DROP COLUMN ?", table, column).Error; err != nil {
+		m.DB.Exec("ALTER TABLE ? DROP COLUMN ?", table, tempColumn)
+		return err
+	}
+	if err := m.DB.Exec("ALTER TABLE ? RENAME COLUMN ? TO ?", table, tempColumn, column).Error; err != nil {
+		return fmt.Errorf("failed to rename the temporary column %s of %s to %s, the values are kept in it: %w", tempColumn.Name, stmt.Table, field.DBName, err)
+	}
+	if field.NotNull || field.PrimaryKey {
+		if err := m.DB.Exec("ALTER TABLE ? MODIFY (? NOT NULL)", table, column).Error; err != nil {
+			return err
+		}
+	}
+	if field.Comment != "" {
+		return m.commentOnColumn(stmt, field)
+	}
+	return nil
+}
+
+// tempColumnName returns the name of the temporary column used by convertColumn, e.g.: REMARK_TMP,
+// which is shortened to maxLength bytes, and is quoted if the column is quoted.
+func tempColumnName(name string, maxLength int) string {
+	if isQuoted(name) {
+		return ShortenIdentifier(name[:len(name)-1]+`_TMP"`, maxLength)
+	}
+	return ShortenIdentifier(name+"_TMP", maxLength)
+}
+
+// checkNarrowing returns ErrColumnNarrowed if the existing data do not fit the decreased length,
+// precision or scale of the column.
+func (m Migrator) checkNarrowing(stmt *gorm.Statement, field *schema.Field, from, to dataType) error {
+	var condition string
+	switch {
+	case to.isCharacter() && to.sized && (from.isLOB() || from.isCharacter() && from.size > to.size):
+		length := "LENGTHB"
+		if to.semantics == "CHAR" || to.semantics == "" && from.semantics == "CHAR" || strings.HasPrefix(to.name, "N") {
+			length = "LENGTH"
+		}
+		condition = length + "(?) > " + strconv.FormatInt(to.size, 10)
+	case to.name == "NUMBER" && from.name == "NUMBER" && to.sized &&
+		(!from.sized || to.precision() < from.precision() || to.scale < from.scale):
+		condition = "? IS NOT NULL"
+	default:
+		return nil
+	}
+
+	var count int64
+	if err := m.DB.Raw(
+		"SELECT COUNT(*) FROM ? WHERE "+condition, clause.Table{Name: stmt.Table}, clause.Column{Name: field.DBName},
+	).Row().Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s of %s from %s to %s, %d rows", ErrColumnNarrowed, field.DBName, stmt.Table, from.text, to.text, count)
+	}
+	return nil
+}
+
+// alterDataType returns the data type of the field for MODIFY, without the nullability
+// and the identity, which can not be added to or removed from the existing column.
+func (m Migrator) alterDataType(field *schema.Field) string {
+	dataType, _ := splitNullability(m.Migrator.DataTypeOf(field))
+	if idx := strings.Index(strings.ToUpper(dataType), " GENERATED "); idx >= 0 {
+		dataType = strings.TrimSpace(dataType[:idx])
+	}
+	return dataType
+}
+
+// defaultValueOf returns the default value of the field rendered as a literal
+func (m Migrator) defaultValueOf(field *schema.Field) (string, bool) {
+	switch {
+	case !field.HasDefaultValue:
+		return "", false
+	case field.DefaultValueInterface != nil:
+		return m.Dialector.Explain("?", field.DefaultValueInterface), true
+	case field.DefaultValue != "" && field.DefaultValue != "(-)":
+		return field.DefaultValue, true
+	}
+	return "", false
+}
+
+// splitNullability splits the trailing NULL or NOT NULL from the data type, e.g.: DATE NULL
+func splitNullability(dataType string) (string, string) {
+	dataType = strings.TrimSpace(dataType)
+	upper := strings.ToUpper(dataType)
+	for _, nullability := range []string{"NOT NULL", "NULL"} {
+		if strings.HasSuffix(upper, " "+nullability) {
+			return strings.TrimSpace(dataType[:len(dataType)-len(nullability)]), nullability
+		}
+	}
+	return dataType, ""
+}
+
+// dataType is the data type parsed from its text, e.g.: VARCHAR2(100 CHAR)
+type dataType struct {
+	// text is the data type in upper case
+	text string
+	// name is the name of the data type, e.g.: VARCHAR2
+	name string
+	// sized is true if the length or precision is specified
+	sized bool
+	// size is the length or precision, 0 for *
+	size int64
+	// scale is the scale of NUMBER
+	scale int64
+	// semantics is the length semantics, CHAR or BYTE, empty if not specified
+	semantics string
+}
+
+func parseDataType(str string) (dt dataType) {
+	dt.text = strings.ToUpper(strings.Join(strings.Fields(str), " "))
+	dt.name = dt.text
+
+	matches := dataTypePattern.FindStringSubmatch(dt.text)
+	if matches == nil {
+		return
+	}
+	dt.name, dt.sized, dt.semantics = matches[1], true, matches[4]
+	dt.size, _ = strconv.ParseInt(matches[2], 10, 64)
+	dt.scale, _ = strconv.ParseInt(matches[3], 10, 64)
+	return
+}
+
+// equal returns true if the data types are the same, the length semantics
+// is ignored if it is not specified by dt2.
+func (dt dataType) equal(dt2 dataType) bool {
+	if dt.name != dt2.name || dt.sized != dt2.sized || dt.size != dt2.size || dt.scale != dt2.scale {
+		return false
+	}
+	// the columns of BYTE semantics are read as VARCHAR2(100)
+	return dt2.semantics == "" || dt.semantics == dt2.semantics ||
+		dt2.semantics == "BYTE" && dt.semantics == ""
+}
+
+// precision returns the precision of NUMBER, which is 38 if it is not specified, e.g.: NUMBER(*,0)
+func (dt dataType) precision() int64 {
+	if !dt.sized || dt.size == 0 {
+		return 38
+	}
+	return dt.size
+}
+
+func (dt dataType) isLOB() bool {
+	switch dt.name {
+	case "CLOB", "NCLOB", "BLOB":
+		return true
+	}
+	return false
+}
+
+func (dt dataType) isCharacter() bool {
+	switch dt.name {
+	case "CHAR", "VARCHAR2", "NCHAR", "NVARCHAR2":
+		return true
+	}
+	return false
+}
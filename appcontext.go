@@ -0,0 +1,233 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ctxKeyAppContext string = "app_context"
+	// ctxKeyAppContextState carries the state of the outer statement to the nested ones
+	ctxKeyAppContextState string = "app_context_state"
+
+	// defaultAppContextPackage is the package setting the application context by default
+	defaultAppContextPackage = "DBMS_SESSION"
+
+	settingKeyAppContext = "oracle:app_context"
+)
+
+// AppContext is an attribute of the application context, e.g.: the tenant of VPD policies
+type AppContext struct {
+	// Namespace is the namespace created by CREATE CONTEXT
+	Namespace string
+	// Attribute is the name of the attribute, read by SYS_CONTEXT(Namespace, Attribute)
+	Attribute string
+	// Value is the value of the attribute
+	Value string
+}
+
+// AppContextsFromContext returns the attributes of the application context carried by ctx
+func AppContextsFromContext(ctx context.Context) []AppContext {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(ctxKeyAppContext).([]AppContext)
+	return attrs
+}
+
+// WithAppContext returns a copy of ctx carrying the attribute of the application context,
+// which is set by DBMS_SESSION.SET_CONTEXT before the statements executed with ctx, and
+// cleared afterwards, so that the VPD policies are enforced by the database:
+//
+//	ctx = oracle.WithAppContext(ctx, "APP_CTX", "TENANT_ID", "42")
+//	db.WithContext(ctx).Find(&orders)
+//
+// The attribute of the same namespace and name carried by ctx is replaced.
+func WithAppContext(ctx context.Context, namespace, attribute, value string) context.Context {
+	var (
+		parents = AppContextsFromContext(ctx)
+		attrs   = make([]AppContext, 0, len(parents)+1)
+	)
+	for _, attr := range parents {
+		if !strings.EqualFold(attr.Namespace, namespace) || !strings.EqualFold(attr.Attribute, attribute) {
+			attrs = append(attrs, attr)
+		}
+	}
+	attrs = append(attrs, AppContext{Namespace: namespace, Attribute: attribute, Value: value})
+	return context.WithValue(ctx, ctxKeyAppContext, attrs)
+}
+
+// appContextState is the state kept from callbackSetAppContext to callbackClearAppContext
+type appContextState struct {
+	attrs []AppContext
+	// pool is the connection pool, transaction or pinned connection setting the attributes
+	pool gorm.ConnPool
+	// conn is the connection pinned from *sql.DB, which is released after the statement
+	conn *sql.Conn
+	// original is the ConnPool of the statement before the connection is pinned
+	original gorm.ConnPool
+	// ctx is the context of the statement before the state is carried
+	ctx context.Context
+	// cleared is true after the attributes are cleared
+	cleared bool
+}
+
+// shares returns true if the statements of pool are executed on the session with the attributes set,
+// e.g.: the default transaction of Create begun on the pinned connection.
+func (s *appContextState) shares(pool gorm.ConnPool) bool {
+	if s.cleared {
+		return false
+	}
+	if s.pool == pool {
+		return true
+	}
+	_, isTx := pool.(gorm.TxCommitter)
+	return s.conn != nil && isTx
+}
+
+// appContextPackage returns the package providing SET_CONTEXT and CLEAR_CONTEXT
+func (dialector Dialector) appContextPackage() (string, error) {
+	pkg := dialector.Config.AppContextPackage
+	if pkg == "" {
+		return defaultAppContextPackage, nil
+	}
+	for _, part := range splitIdentifier(pkg) {
+		if !isIdentifier(part) {
+			return "", fmt.Errorf("invalid AppContextPackage %q", pkg)
+		}
+	}
+	return pkg, nil
+}
+
+// callbackSetAppContext sets the attributes of the application context carried by the context of
+// the statement. The statement is executed on a connection pinned from the pool, so that the
+// attributes are set to the same session, see: WithAppContext
+//
+// The SessionInfo is checked here as well, since the order of the callbacks registered by
+// Before("*") is not kept by the sorting of gorm, see: checkSessionInfo
+func (dialector Dialector) callbackSetAppContext(db *gorm.DB) {
+	dialector.checkSessionInfo(db)
+
+	attrs := AppContextsFromContext(db.Statement.Context)
+	if db.Error != nil || db.DryRun || len(attrs) == 0 {
+		return
+	}
+
+	// the nested statements, e.g.: Preload and the associations of Create, are executed on the
+	// connection of the outer statement with the attributes set, which are cleared by the outer one
+	if outer, ok := db.Statement.Context.Value(ctxKeyAppContextState).(*appContextState); ok && outer.shares(db.Statement.ConnPool) {
+		db.Statement.Settings.Store(settingKeyAppContext, (*appContextState)(nil))
+		return
+	}
+
+	pkg, err := dialector.appContextPackage()
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+
+	state := &appContextState{attrs: attrs, pool: db.Statement.ConnPool, original: db.Statement.ConnPool, ctx: db.Statement.Context}
+	switch pool := db.Statement.ConnPool.(type) {
+	case *sql.DB:
+		if state.conn, err = pool.Conn(db.Statement.Context); err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		state.pool = state.conn
+		db.Statement.ConnPool = state.conn
+	case gorm.TxCommitter:
+		// the statements of a transaction are executed on the same connection
+	default:
+		_ = db.AddError(fmt.Errorf("application context is not supported by the connection pool %T", pool))
+		return
+	}
+	db.Statement.Settings.Store(settingKeyAppContext, state)
+	db.Statement.Context = context.WithValue(db.Statement.Context, ctxKeyAppContextState, state)
+
+	var (
+		block strings.Builder
+		args  []interface{}
+	)
+	block.WriteString("BEGIN ")
+	for _, attr := range attrs {
+		block.WriteString(pkg + ".SET_CONTEXT(" + bindArgs(&args, attr.Namespace, attr.Attribute, attr.Value) + "); ")
+	}
+	block.WriteString("END;")
+
+	if _, err = state.pool.ExecContext(db.Statement.Context, block.String(), args...); err != nil {
+		_ = db.AddError(err)
+	}
+}
+
+// callbackClearAppContext clears the attributes set by callbackSetAppContext,
+// and releases the pinned connection.
+func (dialector Dialector) callbackClearAppContext(db *gorm.DB) {
+	v, ok := db.Statement.Settings.LoadAndDelete(settingKeyAppContext)
+	if !ok {
+		return
+	}
+	state := v.(*appContextState)
+	if state == nil {
+		// the nested statement, see: callbackSetAppContext
+		return
+	}
+	state.cleared = true
+	if db.Statement.Context.Value(ctxKeyAppContextState) == state {
+		db.Statement.Context = state.ctx
+	}
+
+	pkg, err := dialector.appContextPackage()
+	if err == nil {
+		var (
+			block strings.Builder
+			args  []interface{}
+		)
+		block.WriteString("BEGIN ")
+		for _, attr := range state.attrs {
+			block.WriteString(pkg + ".CLEAR_CONTEXT(" + bindArgs(&args, attr.Namespace) + ", NULL, " + bindArgs(&args, attr.Attribute) + "); ")
+		}
+		block.WriteString("END;")
+
+		// the context of statement may be canceled
+		_, err = state.pool.ExecContext(context.Background(), block.String(), args...)
+	}
+
+	if state.conn != nil {
+		if db.Statement.ConnPool == gorm.ConnPool(state.conn) {
+			db.Statement.ConnPool = state.original
+		}
+
+		// the rows of Row callback are read after the callbacks, the connection is released
+		// after they are closed, the session can not be reused by other statements either.
+		switch db.Statement.Dest.(type) {
+		case *sql.Rows, *sql.Row:
+			go state.conn.Close()
+		default:
+			if err != nil {
+				// the attributes are not cleared, the session must not be reused
+				_ = state.conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+			}
+			_ = state.conn.Close()
+		}
+	}
+
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("clear application context failed: %w", err))
+	}
+}
+
+// bindArgs appends the values to args, and returns the bind variables of them
+func bindArgs(args *[]interface{}, values ...string) string {
+	binds := make([]string, len(values))
+	for idx, value := range values {
+		*args = append(*args, value)
+		binds[idx] = ":" + strconv.Itoa(len(*args))
+	}
+	return strings.Join(binds, ", ")
+}
@@ -0,0 +1,135 @@
+package oracle
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const ctxKeySessionInfo string = "session_info"
+
+// ErrSessionInfoNotSet is returned by the statements with SessionInfo, if it can not be set by the
+// connection pool, rather than running the statements without it.
+var ErrSessionInfoNotSet = errors.New("session info can not be set")
+
+// SessionInfo are the attributes of the session shown in v$session, AWR and ASH reports,
+// so that the load can be attributed to the services and code paths.
+type SessionInfo struct {
+	// ClientIdentifier is set by DBMS_SESSION.SET_IDENTIFIER, e.g.: the user of the request
+	ClientIdentifier string
+	// Module is set by DBMS_APPLICATION_INFO.SET_MODULE, e.g.: the name of the service
+	Module string
+	// Action is set by DBMS_APPLICATION_INFO.SET_ACTION, e.g.: the name of the endpoint
+	Action string
+	// ClientInfo is set by DBMS_APPLICATION_INFO.SET_CLIENT_INFO
+	ClientInfo string
+}
+
+// SessionInfoFromContext returns the SessionInfo carried by ctx
+func SessionInfoFromContext(ctx context.Context) SessionInfo {
+	if ctx == nil {
+		return SessionInfo{}
+	}
+	info, _ := ctx.Value(ctxKeySessionInfo).(SessionInfo)
+	return info
+}
+
+// WithSessionInfo returns a copy of ctx carrying info, which is set to the session
+// before the statements executed with ctx:
+//
+//	db.WithContext(oracle.WithSessionInfo(ctx, oracle.SessionInfo{Module: "billing"})).Find(&invoices)
+//
+// The info is set by the wrapped connections of the default DriverName only, ErrSessionInfoNotSet
+// is added if the statements are executed by other pools, e.g.: Config.Conn or gorm.Config.PrepareStmt.
+func WithSessionInfo(ctx context.Context, info SessionInfo) context.Context {
+	return context.WithValue(ctx, ctxKeySessionInfo, info)
+}
+
+// WithClientIdentifier returns a copy of ctx carrying the client identifier, see: WithSessionInfo
+func WithClientIdentifier(ctx context.Context, clientIdentifier string) context.Context {
+	info := SessionInfoFromContext(ctx)
+	info.ClientIdentifier = clientIdentifier
+	return WithSessionInfo(ctx, info)
+}
+
+// WithModule returns a copy of ctx carrying the module and action, see: WithSessionInfo
+//
+//	ctx = oracle.WithModule(ctx, "billing", "close_invoice")
+func WithModule(ctx context.Context, module, action string) context.Context {
+	info := SessionInfoFromContext(ctx)
+	info.Module, info.Action = module, action
+	return WithSessionInfo(ctx, info)
+}
+
+// WithAction returns a copy of ctx carrying the action of the module, see: WithSessionInfo
+func WithAction(ctx context.Context, action string) context.Context {
+	info := SessionInfoFromContext(ctx)
+	info.Action = action
+	return WithSessionInfo(ctx, info)
+}
+
+// WithClientInfo returns a copy of ctx carrying the client info, see: WithSessionInfo
+func WithClientInfo(ctx context.Context, clientInfo string) context.Context {
+	info := SessionInfoFromContext(ctx)
+	info.ClientInfo = clientInfo
+	return WithSessionInfo(ctx, info)
+}
+
+// checkSessionInfo adds ErrSessionInfoNotSet if the statement carries SessionInfo,
+// which can not be set by the connection pool of the statement, see: WithSessionInfo.
+// It is called by callbackSetAppContext before the statements of all processors.
+func (dialector Dialector) checkSessionInfo(db *gorm.DB) {
+	if db.Error != nil || SessionInfoFromContext(db.Statement.Context) == (SessionInfo{}) {
+		return
+	}
+	if !dialector.wrapsConnPool(db.Statement.ConnPool) {
+		_ = db.AddError(fmt.Errorf("%w by the connection pool %T", ErrSessionInfoNotSet, db.Statement.ConnPool))
+	}
+}
+
+// applySessionInfo sets the SessionInfo carried by ctx to the session, the attributes
+// are set by one round trip only when they are changed since the last statement.
+func (c *conn) applySessionInfo(ctx context.Context) error {
+	info := SessionInfoFromContext(ctx)
+	if info == c.info {
+		return nil
+	}
+
+	var (
+		block strings.Builder
+		args  []driver.NamedValue
+	)
+	bind := func(value string) string {
+		args = append(args, driver.NamedValue{Ordinal: len(args) + 1, Value: value})
+		return ":" + strconv.Itoa(len(args))
+	}
+
+	block.WriteString("BEGIN ")
+	if info.ClientIdentifier != c.info.ClientIdentifier {
+		if info.ClientIdentifier == "" {
+			block.WriteString("DBMS_SESSION.CLEAR_IDENTIFIER; ")
+		} else {
+			block.WriteString("DBMS_SESSION.SET_IDENTIFIER(" + bind(info.ClientIdentifier) + "); ")
+		}
+	}
+	if info.Module != c.info.Module {
+		block.WriteString("DBMS_APPLICATION_INFO.SET_MODULE(" + bind(info.Module) + ", " + bind(info.Action) + "); ")
+	} else if info.Action != c.info.Action {
+		block.WriteString("DBMS_APPLICATION_INFO.SET_ACTION(" + bind(info.Action) + "); ")
+	}
+	if info.ClientInfo != c.info.ClientInfo {
+		block.WriteString("DBMS_APPLICATION_INFO.SET_CLIENT_INFO(" + bind(info.ClientInfo) + "); ")
+	}
+	block.WriteString("END;")
+
+	if _, err := c.Connection.ExecContext(ctx, block.String(), args); err != nil {
+		return c.check(err)
+	}
+	c.info = info
+	return nil
+}
@@ -2,11 +2,15 @@ package oracle
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 )
@@ -18,16 +22,26 @@ const (
 	// ClauseValues for clause.ClauseBuilder VALUES key
 	ClauseValues = "VALUES"
 	ClauseLimit  = "LIMIT"
+	// ClauseSelect for clause.ClauseBuilder SELECT key
+	ClauseSelect = "SELECT"
 	// ClauseValues for clause.ClauseBuilder FOR key
 	ClauseFor    = "FOR"
 	ClauseInsert = "INSERT"
+	// ClauseUpdate for clause.ClauseBuilder UPDATE key
+	ClauseUpdate = "UPDATE"
+	// ClauseDelete for clause.ClauseBuilder DELETE key
+	ClauseDelete = "DELETE"
+	// ClauseWhere for clause.ClauseBuilder WHERE key
+	ClauseWhere = "WHERE"
+	// ClauseSet for clause.ClauseBuilder SET key
+	ClauseSet = "SET"
+	// ClauseGroupBy for clause.ClauseBuilder GROUP BY key
+	ClauseGroupBy = "GROUP BY"
 )
 
-type fieldSet struct {
-	idx int
-	f   schema.Field
-	c   clause.Column
-}
+// mergeSourceAlias is the alias of the USING source in MERGE statement,
+// it is named as `excluded` to be compatible with clause.AssignmentColumns.
+const mergeSourceAlias = "excluded"
 
 func (d Dialector) ClauseBuilders() map[string]clause.ClauseBuilder {
 	clauseBuilders := map[string]clause.ClauseBuilder{
@@ -36,8 +50,19 @@ func (d Dialector) ClauseBuilders() map[string]clause.ClauseBuilder {
 		ClauseInsert:     d.HandleInsert,
 		ClauseValues:     d.HandleValues,
 		ClauseLimit:      d.HandleLimit,
+		ClauseSelect:     d.HandleSelect,
+		ClauseUpdate:     d.HandleUpdate,
+		ClauseDelete:     d.HandleDelete,
+		ClauseFor:        d.HandleFor,
+		ClauseWhere:      nil,
+		ClauseSet:        nil,
+		ClauseGroupBy:    nil,
 	}
 
+	// the named parameters of the expressions are rewritten before they are built
+	for name, build := range clauseBuilders {
+		clauseBuilders[name] = bindNamedClause(build)
+	}
 	return clauseBuilders
 }
 
@@ -48,40 +73,89 @@ func (d Dialector) HandleOnConflict(c clause.Clause, builder clause.Builder) {
 		return
 	}
 
-	builder.WriteString("ON DUPLICATE KEY UPDATE ")
-	if len(onConflict.DoUpdates) == 0 {
-		if s := builder.(*gorm.Statement).Schema; s != nil {
-			var column clause.Column
-			onConflict.DoNothing = false
+	stmt := builder.(*gorm.Statement)
+	values, _ := stmt.Clauses[ClauseValues].Expression.(clause.Values)
+
+	// BUILDING SQL: ON (...) WHEN MATCHED THEN UPDATE ... WHEN NOT MATCHED THEN INSERT ...
+	//	the `MERGE INTO` part is written by HandleInsert
+	//	the `USING` part is written by HandleValues
+	conflictColumns := mergeConflictColumns(stmt, onConflict)
+	if len(conflictColumns) == 0 {
+		stmt.AddError(fmt.Errorf("ON CONFLICT: conflict columns are required for MERGE, OnConstraint is not supported"))
+		return
+	}
 
-			if s.PrioritizedPrimaryField != nil {
-				column = clause.Column{Name: s.PrioritizedPrimaryField.DBName}
-			} else if len(s.DBNames) > 0 {
-				column = clause.Column{Name: s.DBNames[0]}
-			}
+	builder.WriteString("ON (")
+	for idx, column := range conflictColumns {
+		if idx > 0 {
+			builder.WriteString(" AND ")
+		}
+		builder.WriteQuoted(clause.Column{Table: stmt.Table, Name: column.Name})
+		builder.WriteByte('=')
+		builder.WriteQuoted(clause.Column{Table: mergeSourceAlias, Name: column.Name})
+	}
+	if len(onConflict.TargetWhere.Exprs) > 0 {
+		builder.WriteString(" AND ")
+		onConflict.TargetWhere.Build(builder)
+	}
+	builder.WriteByte(')')
 
-			if column.Name != "" {
-				onConflict.DoUpdates = []clause.Assignment{{Column: column, Value: column}}
-			}
+	// ORA-38104: Columns referenced in the ON Clause cannot be updated
+	updates := make(clause.Set, 0, len(onConflict.DoUpdates))
+	for _, assignment := range onConflict.DoUpdates {
+		if !containsColumn(conflictColumns, assignment.Column.Name) {
+			updates = append(updates, assignment)
+		}
+	}
+
+	if !onConflict.DoNothing && len(updates) > 0 {
+		builder.WriteString(" WHEN MATCHED THEN UPDATE SET ")
+		updates.Build(builder)
+
+		if len(onConflict.Where.Exprs) > 0 {
+			builder.WriteString(" WHERE ")
+			onConflict.Where.Build(builder)
 		}
 	}
 
-	for idx, assignment := range onConflict.DoUpdates {
+	// sequence columns always use the NEXTVAL of the sequence when inserting.
+	builder.WriteString(" WHEN NOT MATCHED THEN INSERT (")
+	seqFields := sequenceFields(stmt.Schema)
+	for idx, column := range values.Columns {
 		if idx > 0 {
 			builder.WriteByte(',')
 		}
+		builder.WriteQuoted(column)
+	}
+	for _, field := range seqFields {
+		if !containsColumn(values.Columns, field.DBName) {
+			if len(values.Columns) > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(field.DBName)
+		}
+	}
 
-		builder.WriteQuoted(assignment.Column)
-		builder.WriteByte('=')
-		if column, ok := assignment.Value.(clause.Column); ok && column.Table == "excluded" {
-			column.Table = ""
-			builder.WriteString("VALUES(")
-			builder.WriteQuoted(column)
-			builder.WriteByte(')')
+	builder.WriteString(") VALUES (")
+	for idx, column := range values.Columns {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		if field := lookUpSequenceField(seqFields, column.Name); field != nil {
+			builder.WriteString(fmt.Sprintf("%s.NEXTVAL", stmt.Quote(field.TagSettings["SEQUENCE"])))
 		} else {
-			builder.AddVar(builder, assignment.Value)
+			builder.WriteQuoted(clause.Column{Table: mergeSourceAlias, Name: column.Name})
 		}
 	}
+	for _, field := range seqFields {
+		if !containsColumn(values.Columns, field.DBName) {
+			if len(values.Columns) > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteString(fmt.Sprintf("%s.NEXTVAL", stmt.Quote(field.TagSettings["SEQUENCE"])))
+		}
+	}
+	builder.WriteByte(')')
 }
 
 func (d Dialector) HandleValues(c clause.Clause, builder clause.Builder) {
@@ -92,77 +166,50 @@ func (d Dialector) HandleValues(c clause.Clause, builder clause.Builder) {
 	}
 
 	stmt := builder.(*gorm.Statement)
-	values = d.addSequenceColumn(stmt, values)
-	values.MergeClause(&c)
-
-	colCount := len(values.Columns)
-	valCount := len(values.Values)
-
-	if isBatchInsert, _ := stmt.Context.Value(ctxKeyIsBatchInsert).(bool); isBatchInsert {
-		if isReturning, _ := hasReturning(stmt); isReturning {
+	if onConflict, isMerge := isMerge(stmt); isMerge {
+		// BUILDING SQL: USING (SELECT :p1 AS col1, :p2 AS col2 FROM DUAL UNION ALL ...) excluded
+		conflictColumns := mergeConflictColumns(stmt, onConflict)
 
-			//  DECLARE
-			//    TYPE t_forall_test_tab IS TABLE OF allen_test%ROWTYPE;
-			//    r  t_forall_test_tab := t_forall_test_tab();
-			//    l_size NUMBER := 3;
-			//  BEGIN
-			//    -- Populate collection.
-			//    FOR i IN 1 .. l_size LOOP
-			//      r.extend;
-			//      r(r.last).id := allen_test_s.nextval;
-			//      :id1 := r(r.last).id;
-			//      r(r.last).col1 := TO_CHAR(i);
-			//      r(r.last).col2 := 'Description: ' || TO_CHAR(i);
-			//    END LOOP;
-			//    -- Time bulk inserts.
-			//    FORALL i IN r.first .. r.last
-			//      INSERT INTO allen_test VALUES r (i);
-			//    COMMIT;
-			//  END;
-
-			allFields := make([]fieldSet, colCount)
-			for i := 0; i < colCount; i++ {
-				colName := values.Columns[i].Name
-				for idx, f := range stmt.Schema.Fields {
-					if f.DBName == colName {
-						allFields[i] = fieldSet{idx, *f, values.Columns[i]}
-						break
-					}
-				}
+		builder.WriteString("USING (")
+		for i, row := range values.Values {
+			if i > 0 {
+				builder.WriteString(" UNION ALL ")
 			}
-			colInsert := ""
-			colCount := len(values.Columns)
-			for i := 0; i < colCount; i++ {
-				if i > 0 {
-					colInsert += ","
+			builder.WriteString("SELECT ")
+			for j, column := range values.Columns {
+				if j > 0 {
+					builder.WriteByte(',')
 				}
-				colInsert += values.Columns[i].Name
+				stmt.AddVar(builder, row[j])
+				builder.WriteString(" AS ")
+				builder.WriteQuoted(column.Name)
 			}
 
-			builder.WriteString("DECLARE\n")
-			builder.WriteString(fmt.Sprintf("\tTYPE t IS TABLE OF %s%%ROWTYPE;\n", stmt.Schema.Table))
-			builder.WriteString("\tr t := t();\n")
-			builder.WriteString("BEGIN\n")
-			for i := 0; i < valCount; i++ {
-				builder.WriteString("\tr.extend;\n")
-				for j := 0; j < colCount; j++ {
-					fs := allFields[j]
-					para := ""
-					if seqName, isSeq := fs.f.TagSettings["SEQUENCE"]; isSeq {
-						builder.WriteString(fmt.Sprintf("\t:p%d_%d := %s.NEXTVAL;\n", i, fs.idx, seqName))
-						para = fmt.Sprintf(":p%d_%d", i, fs.idx)
-					} else {
-						para = fmt.Sprintf(":p%d_%d", i, fs.idx)
+			// the conflict columns not provided never match, e.g.: zero valued primary keys
+			written := len(values.Columns)
+			for _, column := range conflictColumns {
+				if !containsColumn(values.Columns, column.Name) {
+					if written > 0 {
+						builder.WriteByte(',')
 					}
-					builder.WriteString(fmt.Sprintf("\tr(r.last).%s := %s;\n", fs.f.DBName, para))
+					builder.WriteString("NULL AS ")
+					builder.WriteQuoted(column.Name)
+					written++
 				}
-				stmt.Vars = append(stmt.Vars, values.Values[i]...)
 			}
-			builder.WriteString("\tFORALL i IN r.first .. r.last\n")
-			builder.WriteString(fmt.Sprintf("\t\tINSERT INTO %s VALUES r (i);\n", stmt.Schema.Table))
-			// builder.WriteString("\tDBMS_OUTPUT.PUT_LINE(TO_Char(SQL%ROWCOUNT)||' rows affected.');\n")
-			builder.WriteString("\tCOMMIT;\n")
-			builder.WriteString("END;")
+			builder.WriteString(" FROM DUAL")
+		}
+		builder.WriteString(") ")
+		builder.WriteString(mergeSourceAlias)
+		return
+	}
+
+	values = d.addSequenceColumn(stmt, values)
+	values.MergeClause(&c)
+
+	if isBatchInsert, _ := stmt.Context.Value(ctxKeyIsBatchInsert).(bool); isBatchInsert {
+		if isReturning, _ := hasReturning(stmt); isReturning {
+			d.buildBatchInsertReturning(stmt, builder, values)
 		} else {
 
 			// INSERT INTO tableName (id,col1,col2)
@@ -184,9 +231,9 @@ func (d Dialector) HandleValues(c clause.Clause, builder clause.Builder) {
 
 				field := stmt.Schema.Fields[i]
 
-				colInsert += values.Columns[i].Name
+				colInsert += stmt.Quote(values.Columns[i].Name)
 				if seqName, isSeq := field.TagSettings["SEQUENCE"]; isSeq {
-					colSelect += fmt.Sprintf("%s.NEXTVAL", seqName)
+					colSelect += fmt.Sprintf("%s.NEXTVAL", stmt.Quote(seqName))
 				} else {
 					colSelect += field.Name
 				}
@@ -213,6 +260,135 @@ func (d Dialector) HandleValues(c clause.Clause, builder clause.Builder) {
 	}
 }
 
+// buildBatchInsertReturning builds a PL/SQL block to insert multiple rows and returning values.
+// The block does not COMMIT, so that it runs inside the transaction of the caller.
+//
+//	DECLARE
+//		TYPE t IS TABLE OF Customers%ROWTYPE;
+//		r t := t();
+//		TYPE t_o0 IS TABLE OF Customers.CUSTOMER_ID%TYPE;
+//		o0 t_o0;
+//	BEGIN
+//		r.extend;
+//		r(r.last).CUSTOMER_NAME := :p0;
+//		r.extend;
+//		r(r.last).CUSTOMER_NAME := :p1;
+//		FORALL i IN r.first .. r.last
+//			INSERT INTO Customers (CUSTOMER_NAME,CUSTOMER_ID) VALUES (r(i).CUSTOMER_NAME,CUSTOMERS_S.NEXTVAL)
+//			RETURNING CUSTOMER_ID BULK COLLECT INTO o0;
+//		:rows_affected := SQL%ROWCOUNT;
+//		:o0_0 := o0(1);
+//		:o1_0 := o0(2);
+//	END;
+func (d Dialector) buildBatchInsertReturning(stmt *gorm.Statement, builder clause.Builder, values clause.Values) {
+	returningFields := returningFields(stmt)
+	seqFields := sequenceFields(stmt.Schema)
+
+	builder.WriteString("DECLARE\n")
+	builder.WriteString(fmt.Sprintf("\tTYPE t IS TABLE OF %s%%ROWTYPE;\n", stmt.Quote(clause.Table{Name: stmt.Table})))
+	builder.WriteString("\tr t := t();\n")
+	for k, f := range returningFields {
+		builder.WriteString(fmt.Sprintf("\tTYPE t_o%d IS TABLE OF %s%%TYPE;\n", k, stmt.Quote(clause.Column{Table: stmt.Table, Name: f.DBName})))
+		builder.WriteString(fmt.Sprintf("\to%d t_o%d;\n", k, k))
+	}
+
+	builder.WriteString("BEGIN\n")
+	for i := 0; i < len(values.Values); i++ {
+		builder.WriteString("\tr.extend;\n")
+		for j, column := range values.Columns {
+			// sequence columns are generated by NEXTVAL when inserting
+			if lookUpSequenceField(seqFields, column.Name) != nil {
+				continue
+			}
+			builder.WriteString(fmt.Sprintf("\tr(r.last).%s := ", stmt.Quote(column.Name)))
+			stmt.AddVar(builder, values.Values[i][j])
+			builder.WriteString(";\n")
+		}
+	}
+
+	builder.WriteString("\tFORALL i IN r.first .. r.last\n")
+	builder.WriteString("\t\tINSERT INTO ")
+	builder.WriteQuoted(clause.Table{Name: stmt.Table})
+	builder.WriteString(" (")
+	for j, column := range values.Columns {
+		if j > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteQuoted(column.Name)
+	}
+	builder.WriteString(") VALUES (")
+	for j, column := range values.Columns {
+		if j > 0 {
+			builder.WriteByte(',')
+		}
+		if field := lookUpSequenceField(seqFields, column.Name); field != nil {
+			builder.WriteString(fmt.Sprintf("%s.NEXTVAL", stmt.Quote(field.TagSettings["SEQUENCE"])))
+		} else {
+			builder.WriteString(fmt.Sprintf("r(i).%s", stmt.Quote(column.Name)))
+		}
+	}
+	builder.WriteString(")")
+
+	if len(returningFields) > 0 {
+		builder.WriteString("\n\t\tRETURNING ")
+		for k, f := range returningFields {
+			if k > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(f.DBName)
+		}
+		builder.WriteString(" BULK COLLECT INTO ")
+		for k := range returningFields {
+			if k > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteString(fmt.Sprintf("o%d", k))
+		}
+	}
+	builder.WriteString(";\n")
+
+	// the real rows affected, see: callbackRowsAffected
+	rowsAffected := new(int64)
+	stmt.Context = context.WithValue(stmt.Context, ctxKeyRowsAffected, rowsAffected)
+	stmt.Vars = append(stmt.Vars, rowsAffected)
+	builder.WriteString("\t:rows_affected := SQL%ROWCOUNT;\n")
+
+	// write the returning values back into each element
+	for i := 0; i < len(values.Values); i++ {
+		rv := reflect.Indirect(stmt.ReflectValue.Index(i))
+		for k, f := range returningFields {
+			stmt.Vars = append(stmt.Vars, f.ReflectValueOf(stmt.Context, rv).Addr().Interface())
+			builder.WriteString(fmt.Sprintf("\t:o%d_%d := o%d(%d);\n", i, k, k, i+1))
+		}
+	}
+
+	builder.WriteString("END;")
+}
+
+// returningFields returns the fields of RETURNING clause,
+// all the fields are returned for `RETURNING *`.
+func returningFields(stmt *gorm.Statement) []*schema.Field {
+	fields := make([]*schema.Field, 0)
+	if stmt.Schema == nil {
+		return fields
+	}
+
+	returning, _ := stmt.Clauses[ClauseReturning].Expression.(clause.Returning)
+	if len(returning.Columns) == 0 || (len(returning.Columns) == 1 && returning.Columns[0].Name == "*") {
+		for _, dbName := range stmt.Schema.DBNames {
+			fields = append(fields, stmt.Schema.FieldsByDBName[dbName])
+		}
+		return fields
+	}
+
+	for _, column := range returning.Columns {
+		if f := stmt.Schema.LookUpField(column.Name); f != nil {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
 func (d Dialector) HandleReturning(c clause.Clause, builder clause.Builder) {
 	returning, ok := c.Expression.(clause.Returning)
 	if !ok {
@@ -222,7 +398,12 @@ func (d Dialector) HandleReturning(c clause.Clause, builder clause.Builder) {
 
 	stmt := builder.(*gorm.Statement)
 	isBatchInsert, _ := stmt.Context.Value(ctxKeyIsBatchInsert).(bool)
-	if isBatchInsert {
+	if isUpdateOrDeleteReturning(stmt) {
+		// BUILDING SQL: RETURNING ... BULK COLLECT INTO ...
+		d.buildReturningCollect(stmt, builder)
+	} else if _, isMerge := isMerge(stmt); isMerge {
+		// MERGE does not support RETURNING
+	} else if isBatchInsert {
 		// do nothing
 	} else {
 		// RETURNING id INTO l_id;
@@ -239,7 +420,7 @@ func (d Dialector) HandleReturning(c clause.Clause, builder clause.Builder) {
 			if i > 0 {
 				builder.WriteByte(',')
 			}
-			builder.WriteString(colName)
+			builder.WriteQuoted(colName)
 
 			for idx, f := range stmt.Schema.Fields {
 				if f.DBName == colName {
@@ -271,6 +452,21 @@ func (d Dialector) HandleInsert(c clause.Clause, builder clause.Builder) {
 	}
 
 	stmt := builder.(*gorm.Statement)
+	if _, isMerge := isMerge(stmt); isMerge {
+		// BUILDING SQL: MERGE INTO
+		// MERGE does not support RETURNING, remove it to execute the statement
+		// and get the RowsAffected.
+		delete(stmt.Clauses, ClauseReturning)
+
+		table := insertClause.Table
+		if table.Name == "" {
+			table = clause.Table{Name: stmt.Table}
+		}
+		builder.WriteString("MERGE INTO ")
+		builder.WriteQuoted(table)
+		return
+	}
+
 	// batch insert
 	isBatchInsert := false
 	k := stmt.ReflectValue.Kind()
@@ -288,58 +484,315 @@ func (d Dialector) HandleInsert(c clause.Clause, builder clause.Builder) {
 	}
 }
 
+func (d Dialector) HandleUpdate(c clause.Clause, builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok && isUpdateOrDeleteReturning(stmt) {
+		// BUILDING SQL: DECLARE ... BEGIN UPDATE
+		d.buildReturningDeclare(stmt, builder)
+	}
+
+	c.Build(builder)
+}
+
+func (d Dialector) HandleDelete(c clause.Clause, builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok && isUpdateOrDeleteReturning(stmt) {
+		// BUILDING SQL: DECLARE ... BEGIN DELETE
+		d.buildReturningDeclare(stmt, builder)
+	}
+
+	c.Build(builder)
+}
+
+func (d Dialector) HandleSelect(c clause.Clause, builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		d.keepColumnAliases(stmt, c)
+	}
+
+	if stmt, ok := builder.(*gorm.Statement); ok && hasLimit(stmt) {
+		if hasLocking(stmt) {
+			// the rows are limited by the subquery before the locked ones are skipped, the workers would
+			// get nothing while there are unlocked rows, so the rows of SKIP LOCKED are limited by the scan
+			// of Find instead, see: callbackQuery
+			if _, ok := skipLockedLimit(stmt); ok {
+				stmt.AddError(ErrSkipLockedWithLimit)
+				return
+			}
+
+			// BUILDING SQL: SELECT ... FROM ... WHERE t.ROWID IN (SELECT rid FROM (SELECT a.rid, ROWNUM rn FROM (SELECT t.ROWID rid
+			// the rest part is written by HandleLimit
+			// ROWID is a pseudocolumn, which can not be quoted
+			table := clause.Table{Name: clause.CurrentTable}
+			c.Build(builder)
+			if from, ok := stmt.Clauses["FROM"]; ok {
+				builder.WriteByte(' ')
+				from.Build(builder)
+			}
+			builder.WriteString(" WHERE ")
+			builder.WriteQuoted(table)
+			builder.WriteString(".ROWID IN (SELECT rid FROM (SELECT a.rid, ROWNUM rn FROM (SELECT ")
+			builder.WriteQuoted(table)
+			builder.WriteString(".ROWID rid")
+			return
+		}
+
+		if !d.Config.capabilities.OffsetFetch {
+			// BUILDING SQL: SELECT * FROM (SELECT a.*, ROWNUM rn FROM (
+			// the rest part is written by HandleLimit
+			builder.WriteString("SELECT * FROM (SELECT a.*, ROWNUM rn FROM (")
+		}
+	}
+
+	c.Build(builder)
+}
+
 func (d Dialector) HandleLimit(c clause.Clause, builder clause.Builder) {
-	if !d.Config.supportOffsetFetch {
+	limit, ok := c.Expression.(clause.Limit)
+	if !ok {
 		c.Build(builder)
 		return
 	}
 
-	if limit, ok := c.Expression.(clause.Limit); ok {
-		if stmt, ok := builder.(*gorm.Statement); ok {
-			if _, ok := stmt.Clauses["ORDER BY"]; !ok {
-				s := stmt.Schema
-				builder.WriteString("ORDER BY ")
-				if s != nil && s.PrioritizedPrimaryField != nil {
-					builder.WriteQuoted(s.PrioritizedPrimaryField.DBName)
-					builder.WriteByte(' ')
-				} else {
-					builder.WriteString("(SELECT NULL FROM DUAL)")
-				}
-			}
+	if limit.Limit <= 0 && limit.Offset <= 0 {
+		return
+	}
+
+	if stmt, ok := builder.(*gorm.Statement); ok && hasLocking(stmt) {
+		// FOR UPDATE is not allowed with ROWNUM views or FETCH FIRST (ORA-02014),
+		// the rows to lock are selected by ROWID in the form of:
+		//
+		//	SELECT ... FROM ... WHERE t.ROWID IN (
+		//		SELECT rid FROM (
+		//			SELECT a.rid, ROWNUM rn FROM (
+		//				SELECT t.ROWID rid FROM ... WHERE ... ORDER BY ...
+		//			) a WHERE ROWNUM <= :limit + :offset
+		//		) WHERE rn > :offset
+		//	) ORDER BY ... FOR UPDATE ...
+		builder.WriteString(") a")
+		if limit.Limit > 0 {
+			builder.WriteString(" WHERE ROWNUM <= ")
+			builder.WriteString(strconv.Itoa(limit.Offset + limit.Limit))
 		}
+		builder.WriteString(") WHERE rn > ")
+		builder.WriteString(strconv.Itoa(limit.Offset))
+		builder.WriteByte(')')
 
-		if offset := limit.Offset; offset > 0 {
-			builder.WriteString(" OFFSET ")
-			builder.WriteString(strconv.Itoa(offset))
-			builder.WriteString(" ROWS")
+		if orderBy, ok := stmt.Clauses["ORDER BY"]; ok {
+			builder.WriteByte(' ')
+			orderBy.Build(builder)
 		}
+		return
+	}
 
-		var lmt = -1
-		if limit.Limit != nil && *limit.Limit >= 0 {
-			lmt = *limit.Limit
+	if !d.Config.capabilities.OffsetFetch {
+		// Oracle 11g and earlier does not support OFFSET ... FETCH ...
+		// the statement is wrapped by ROWNUM in the form of:
+		//
+		//	SELECT * FROM (
+		//		SELECT a.*, ROWNUM rn FROM (
+		//			SELECT ... FROM ... WHERE ... ORDER BY ...
+		//		) a WHERE ROWNUM <= :limit + :offset
+		//	) WHERE rn > :offset
+		//
+		// See: https://blogs.oracle.com/oraclemagazine/post/on-rownum-and-limiting-results
+		builder.WriteString(") a")
+		if limit.Limit > 0 {
+			builder.WriteString(" WHERE ROWNUM <= ")
+			builder.WriteString(strconv.Itoa(limit.Offset + limit.Limit))
 		}
-		if lmt >= 0 || limit.Offset > 0 {
-			builder.WriteString(" FETCH NEXT ")
-			builder.WriteString(strconv.Itoa(lmt))
-			builder.WriteString(" ROWS ONLY")
+		builder.WriteString(") WHERE rn > ")
+		builder.WriteString(strconv.Itoa(limit.Offset))
+		return
+	}
+
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		if _, ok := stmt.Clauses["ORDER BY"]; !ok {
+			s := stmt.Schema
+			builder.WriteString("ORDER BY ")
+			if s != nil && s.PrioritizedPrimaryField != nil {
+				builder.WriteQuoted(s.PrioritizedPrimaryField.DBName)
+			} else {
+				builder.WriteString("(SELECT NULL FROM DUAL)")
+			}
+			builder.WriteByte(' ')
 		}
 	}
+
+	if offset := limit.Offset; offset > 0 {
+		builder.WriteString("OFFSET ")
+		builder.WriteString(strconv.Itoa(offset))
+		builder.WriteString(" ROWS")
+		if limit.Limit > 0 {
+			builder.WriteByte(' ')
+		}
+	}
+
+	if limit.Limit > 0 {
+		builder.WriteString("FETCH NEXT ")
+		builder.WriteString(strconv.Itoa(limit.Limit))
+		builder.WriteString(" ROWS ONLY")
+	}
 }
 
-// addSequenceColumn add sequence column
-func (dialector Dialector) addSequenceColumn(stmt *gorm.Statement, values clause.Values) clause.Values {
-	dbNameCount := len(stmt.Schema.DBNames)
+// ErrSkipLockedWithLimit is returned by Row, Rows and Scan when FOR UPDATE SKIP LOCKED is used with
+// Limit or Offset, which are applied by the scan of Find, First, Take and Last only, see: callbackQuery.
+var ErrSkipLockedWithLimit = errors.New("SKIP LOCKED with Limit or Offset is supported by Find, First, Take and Last only")
 
-	isExists := func(cols []clause.Column, colName string) bool {
-		if cols != nil {
-			for i := 0; i < len(cols); i++ {
-				if cols[i].Name == colName {
-					return true
-				}
+// lockingWait matches the `WAIT n` option of FOR UPDATE
+var lockingWait = regexp.MustCompile(`^WAIT\s+\d+$`)
+
+func (d Dialector) HandleFor(c clause.Clause, builder clause.Builder) {
+	locking, ok := c.Expression.(clause.Locking)
+	if !ok {
+		c.Build(builder)
+		return
+	}
+
+	stmt := builder.(*gorm.Statement)
+
+	// Oracle supports row locks of FOR UPDATE only
+	// See: https://docs.oracle.com/cd/B19306_01/server.102/b14200/statements_10002.htm#i2126016
+	if strength := strings.ToUpper(strings.TrimSpace(locking.Strength)); strength != "UPDATE" {
+		stmt.AddError(fmt.Errorf("locking strength %q is not supported, only UPDATE is supported", locking.Strength))
+		return
+	}
+
+	builder.WriteString("FOR UPDATE")
+
+	// FOR UPDATE OF column: a column name with table, e.g. `Customers.CUSTOMER_ID`,
+	// or a table name to lock by its primary key.
+	if name := locking.Table.Name; name != "" {
+		builder.WriteString(" OF ")
+		if strings.Contains(name, ".") {
+			builder.WriteQuoted(name)
+		} else if column, ok := lockingColumnOf(stmt, locking.Table); ok {
+			builder.WriteQuoted(column)
+		} else {
+			stmt.AddError(fmt.Errorf("can not find primary key to lock table %s", name))
+			return
+		}
+	}
+
+	switch options := lockingOptions(locking); {
+	case options == "":
+	case options == "NOWAIT", options == "SKIP LOCKED", lockingWait.MatchString(options):
+		builder.WriteByte(' ')
+		builder.WriteString(options)
+	default:
+		stmt.AddError(fmt.Errorf("locking options %q is not supported, NOWAIT, WAIT n or SKIP LOCKED expected", locking.Options))
+	}
+}
+
+// lockingOptions returns the options of FOR UPDATE in upper case, e.g.: SKIP LOCKED
+func lockingOptions(locking clause.Locking) string {
+	return strings.ToUpper(strings.Join(strings.Fields(locking.Options), " "))
+}
+
+func isSkipLocked(locking clause.Locking) bool {
+	return lockingOptions(locking) == "SKIP LOCKED"
+}
+
+// skipLockedLimit returns the Limit of the statement with FOR UPDATE SKIP LOCKED
+func skipLockedLimit(stmt *gorm.Statement) (clause.Limit, bool) {
+	locking, ok := stmt.Clauses[ClauseFor].Expression.(clause.Locking)
+	if !ok || !isSkipLocked(locking) || !hasLimit(stmt) {
+		return clause.Limit{}, false
+	}
+	return stmt.Clauses[ClauseLimit].Expression.(clause.Limit), true
+}
+
+// callbackQuery replaces gorm:query, the rows of FOR UPDATE SKIP LOCKED with Limit or Offset are
+// selected without the row limiting, and the scan skips the first Offset rows and stops after Limit
+// rows, since the locked rows are skipped while fetching, e.g.: the job queue
+//
+//	SELECT * FROM JOBS WHERE STATUS = :p1 ORDER BY ID FOR UPDATE SKIP LOCKED
+//
+// The rows are locked when they are fetched, the rows prefetched by the driver are locked as well.
+func callbackQuery(db *gorm.DB) {
+	limit, ok := skipLockedLimit(db.Statement)
+	if !ok {
+		callbacks.Query(db)
+		return
+	}
+
+	if db.Error == nil {
+		// the rows are selected without the row limiting
+		limitClause := db.Statement.Clauses[ClauseLimit]
+		delete(db.Statement.Clauses, ClauseLimit)
+		callbacks.BuildQuerySQL(db)
+		db.Statement.Clauses[ClauseLimit] = limitClause
+
+		if !db.DryRun && db.Error == nil {
+			rows, err := db.Statement.ConnPool.QueryContext(db.Statement.Context, db.Statement.SQL.String(), db.Statement.Vars...)
+			if err != nil {
+				db.AddError(err)
+				return
 			}
+			defer func() {
+				db.AddError(rows.Close())
+			}()
+			gorm.Scan(&limitedRows{Rows: rows, offset: limit.Offset, limit: limit.Limit}, db, 0)
+		}
+	}
+}
+
+// limitedRows skips the first offset rows and stops after limit rows
+type limitedRows struct {
+	gorm.Rows
+	offset  int
+	limit   int
+	fetched int
+}
+
+// Next implements gorm.Rows interface
+func (r *limitedRows) Next() bool {
+	for ; r.offset > 0; r.offset-- {
+		if !r.Rows.Next() {
+			return false
 		}
+	}
+	if r.limit > 0 && r.fetched >= r.limit {
 		return false
 	}
+	r.fetched++
+	return r.Rows.Next()
+}
+
+// lockingColumnOf returns the primary key column of the table to lock
+func lockingColumnOf(stmt *gorm.Statement, table clause.Table) (clause.Column, bool) {
+	if stmt.Schema == nil {
+		return clause.Column{}, false
+	}
+
+	if table.Name == clause.CurrentTable || table.Name == stmt.Table {
+		if pf := stmt.Schema.PrioritizedPrimaryField; pf != nil {
+			return clause.Column{Table: clause.CurrentTable, Name: pf.DBName}, true
+		}
+		return clause.Column{}, false
+	}
+
+	// the joined table
+	if relation, ok := stmt.Schema.Relationships.Relations[table.Name]; ok {
+		if pf := relation.FieldSchema.PrioritizedPrimaryField; pf != nil {
+			return clause.Column{Table: table.Name, Name: pf.DBName}, true
+		}
+	}
+	return clause.Column{}, false
+}
+
+// hasLimit returns true if the statement has LIMIT or OFFSET
+func hasLimit(stmt *gorm.Statement) bool {
+	limit, ok := stmt.Clauses[ClauseLimit].Expression.(clause.Limit)
+	return ok && (limit.Limit > 0 || limit.Offset > 0)
+}
+
+// hasLocking returns true if the statement has FOR UPDATE
+func hasLocking(stmt *gorm.Statement) bool {
+	_, ok := stmt.Clauses[ClauseFor].Expression.(clause.Locking)
+	return ok
+}
+
+// addSequenceColumn add sequence column
+func (dialector Dialector) addSequenceColumn(stmt *gorm.Statement, values clause.Values) clause.Values {
+	dbNameCount := len(stmt.Schema.DBNames)
 
 	isBatchInsert, _ := stmt.Context.Value(ctxKeyIsBatchInsert).(bool)
 
@@ -362,7 +815,7 @@ func (dialector Dialector) addSequenceColumn(stmt *gorm.Statement, values clause
 			//		need to insert/append the column & value.
 			//  case 2: db.Clauses(clause.Returning{Columns: []clause.Column{{Name: "CUSTOMER_ID"},},})
 			// 		need to overwrite the exists value.
-			exists := isExists(values.Columns, db)
+			exists := containsColumn(values.Columns, db)
 			// insert at index i
 			if i < len(values.Columns) {
 				if !exists {
@@ -428,7 +881,7 @@ func (dialector Dialector) bindVarParameter(stmt *gorm.Statement) string {
 				return "NULL"
 			}
 		} else {
-			return fmt.Sprintf("%s.NEXTVAL", seqName)
+			return fmt.Sprintf("%s.NEXTVAL", stmt.Quote(seqName))
 		}
 
 	} else {
@@ -446,6 +899,63 @@ func (dialector Dialector) bindVarParameter(stmt *gorm.Statement) string {
 	return ""
 }
 
+// isMerge returns true if the INSERT statement should be built as MERGE
+func isMerge(stmt *gorm.Statement) (clause.OnConflict, bool) {
+	if c, ok := stmt.Clauses[ClauseOnConflict]; ok {
+		if onConflict, ok := c.Expression.(clause.OnConflict); ok {
+			return onConflict, true
+		}
+	}
+	return clause.OnConflict{}, false
+}
+
+// mergeConflictColumns returns the columns to build the ON condition of MERGE,
+// the primary keys are used if no columns specified.
+func mergeConflictColumns(stmt *gorm.Statement, onConflict clause.OnConflict) []clause.Column {
+	if len(onConflict.Columns) > 0 || stmt.Schema == nil {
+		return onConflict.Columns
+	}
+
+	columns := make([]clause.Column, 0, len(stmt.Schema.PrimaryFields))
+	for _, field := range stmt.Schema.PrimaryFields {
+		columns = append(columns, clause.Column{Name: field.DBName})
+	}
+	return columns
+}
+
+// sequenceFields returns the fields tagged with `sequence`
+func sequenceFields(s *schema.Schema) []*schema.Field {
+	if s == nil {
+		return nil
+	}
+
+	fields := make([]*schema.Field, 0)
+	for _, field := range s.Fields {
+		if _, isSeq := field.TagSettings["SEQUENCE"]; isSeq && field.DBName != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func lookUpSequenceField(fields []*schema.Field, colName string) *schema.Field {
+	for _, field := range fields {
+		if field.DBName == colName {
+			return field
+		}
+	}
+	return nil
+}
+
+func containsColumn(cols []clause.Column, colName string) bool {
+	for i := 0; i < len(cols); i++ {
+		if cols[i].Name == colName {
+			return true
+		}
+	}
+	return false
+}
+
 // hasReturning see: gorm/callbacks/helper.go:L96
 func hasReturning(stmt *gorm.Statement) (bool, gorm.ScanMode) {
 	if c, ok := stmt.Clauses["RETURNING"]; ok {
@@ -0,0 +1,104 @@
+package oracle
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// TableCommenter is implemented by the models having the table comment, which is set by
+// COMMENT ON TABLE when the table is created by the Migrator.
+//
+//	func (Customer) TableComment() string { return "the customers" }
+type TableCommenter interface {
+	TableComment() string
+}
+
+// CreateTable creates the tables by gorm, and then sets the comments of them and their columns,
+// since the comments are not part of CREATE TABLE in Oracle.
+func (m Migrator) CreateTable(values ...interface{}) error {
+	if err := m.Migrator.CreateTable(values...); err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			if stmt.Schema == nil {
+				return nil
+			}
+
+			if commenter, ok := reflect.New(stmt.Schema.ModelType).Interface().(TableCommenter); ok {
+				if comment := commenter.TableComment(); comment != "" {
+					if err := m.DB.Exec("COMMENT ON TABLE " + stmt.Quote(clause.Table{Name: stmt.Table}) + " IS " + quoteString(comment)).Error; err != nil {
+						return err
+					}
+				}
+			}
+
+			for _, dbName := range stmt.Schema.DBNames {
+				field := stmt.Schema.FieldsByDBName[dbName]
+				if !field.IgnoreMigration && field.Comment != "" {
+					if err := m.commentOnColumn(stmt, field); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddColumn adds the column by gorm, and then sets the comment of it
+func (m Migrator) AddColumn(value interface{}, name string) error {
+	if err := m.Migrator.AddColumn(value, name); err != nil {
+		return err
+	}
+
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if field := stmt.Schema.LookUpField(name); field != nil && !field.IgnoreMigration && field.Comment != "" {
+			return m.commentOnColumn(stmt, field)
+		}
+		return nil
+	})
+}
+
+// MigrateColumn migrates the column by gorm, except that the comment is compared with
+// ALL_COL_COMMENTS and changed by COMMENT ON COLUMN, instead of altering the column.
+func (m Migrator) MigrateColumn(value interface{}, field *schema.Field, columnType gorm.ColumnType) error {
+	if err := m.Migrator.MigrateColumn(value, field, uncommentedColumnType{columnType}); err != nil {
+		return err
+	}
+
+	if comment, _ := columnType.Comment(); comment == field.Comment || field.IgnoreMigration {
+		return nil
+	}
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.commentOnColumn(stmt, field)
+	})
+}
+
+// commentOnColumn sets the comment of the column, the empty comment drops it.
+// The comment is a string literal, since the bind variables are not allowed in DDL.
+func (m Migrator) commentOnColumn(stmt *gorm.Statement, field *schema.Field) error {
+	column := stmt.Quote(clause.Column{Table: stmt.Table, Name: field.DBName})
+	return m.DB.Exec("COMMENT ON COLUMN " + column + " IS " + quoteString(field.Comment)).Error
+}
+
+// uncommentedColumnType hides the comment from gorm.Migrator.MigrateColumn,
+// which alters the column when the comment is changed.
+type uncommentedColumnType struct {
+	columnType
+}
+
+// columnType names the embedded gorm.ColumnType, which conflicts with the method ColumnType
+type columnType = gorm.ColumnType
+
+// Comment implements gorm.ColumnType interface
+func (uncommentedColumnType) Comment() (string, bool) {
+	return "", false
+}
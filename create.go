@@ -0,0 +1,227 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Create replaces the `gorm:create` callback,
+// batch inserts are executed by array binding if Config.BatchInsertWithArrayBinding is true.
+func (dialector Dialector) Create(config *callbacks.Config) func(db *gorm.DB) {
+	create := callbacks.Create(config)
+	return func(db *gorm.DB) {
+		if dialector.BatchInsertWithArrayBinding && dialector.canCreateWithArrayBinding(db) {
+			if values, ok := arrayBindingValues(db); ok {
+				createWithArrayBinding(db, values)
+				return
+			}
+		}
+		create(db)
+	}
+}
+
+// canCreateWithArrayBinding returns true if the statement is a batch insert executed by the wrapped
+// go-ora connection, and all the returning columns are sequence columns.
+func (dialector Dialector) canCreateWithArrayBinding(db *gorm.DB) bool {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.SQL.Len() > 0 {
+		return false
+	}
+
+	// the values are bound by go-ora, e.g.: not by the prepared statements of PrepareStmt
+	if !dialector.wrapsConnPool(stmt.ConnPool) {
+		return false
+	}
+
+	if k := stmt.ReflectValue.Kind(); (k != reflect.Slice && k != reflect.Array) || stmt.ReflectValue.Len() == 0 {
+		return false
+	}
+
+	if _, isMerge := isMerge(stmt); isMerge {
+		return false
+	}
+
+	for _, field := range arrayBindingReturningFields(stmt) {
+		if _, isSeq := field.TagSettings["SEQUENCE"]; !isSeq {
+			return false
+		}
+	}
+
+	return true
+}
+
+// arrayBindingReturningFields returns the fields of RETURNING clause,
+// or the fields with default value in database same as gorm.
+func arrayBindingReturningFields(stmt *gorm.Statement) []*schema.Field {
+	if _, ok := stmt.Clauses[ClauseReturning]; ok {
+		return returningFields(stmt)
+	}
+	return stmt.Schema.FieldsWithDefaultDBValue
+}
+
+// arrayBindingValues converts the values to create, it returns false if any value can not be bound by array.
+// The clauses of the statement are not changed, so that the values are created by gorm if it returns false.
+func arrayBindingValues(db *gorm.DB) (clause.Values, bool) {
+	values := callbacks.ConvertToCreateValues(db.Statement)
+
+	for _, row := range values.Values {
+		for j, v := range row {
+			switch vv := v.(type) {
+			case clause.Expr:
+				if strings.ToUpper(vv.SQL) != "NULL" || len(vv.Vars) > 0 {
+					return values, false
+				}
+				row[j] = nil
+			case clause.Expression, gorm.Valuer:
+				return values, false
+			}
+		}
+	}
+
+	return values, true
+}
+
+// createWithArrayBinding executes the batch insert by array binding:
+//
+//	INSERT INTO Customers (CUSTOMER_NAME,AGE,CUSTOMER_ID) VALUES (:1,:2,CUSTOMERS_S.NEXTVAL)
+//
+// The values of sequence columns are fetched in advance if they are returning:
+//
+//	SELECT CUSTOMERS_S.NEXTVAL FROM DUAL CONNECT BY LEVEL <= :1
+func createWithArrayBinding(db *gorm.DB, values clause.Values) {
+	stmt := db.Statement
+	rows := stmt.ReflectValue.Len()
+	if db.Error != nil {
+		return
+	}
+
+	// all the sequence columns are inserted, even if they are not in the values
+	seqFields := sequenceFields(stmt.Schema)
+	for _, field := range seqFields {
+		if !containsColumn(values.Columns, field.DBName) {
+			values.Columns = append(values.Columns, clause.Column{Name: field.DBName})
+			for i := range values.Values {
+				values.Values[i] = append(values.Values[i], nil)
+			}
+		}
+	}
+
+	returningFields := arrayBindingReturningFields(stmt)
+	isReturning := func(field *schema.Field) bool {
+		for _, f := range returningFields {
+			if f == field {
+				return true
+			}
+		}
+		return false
+	}
+
+	var (
+		placeholders = make([]string, 0, len(values.Columns))
+		columns      = make([][]driver.Value, 0, len(values.Columns))
+		columnNames  = make([]string, 0, len(values.Columns))
+	)
+	for j, column := range values.Columns {
+		columnNames = append(columnNames, column.Name)
+
+		field := lookUpSequenceField(seqFields, column.Name)
+		if field != nil && !isReturning(field) {
+			placeholders = append(placeholders, fmt.Sprintf("%s.NEXTVAL", stmt.Quote(field.TagSettings["SEQUENCE"])))
+			continue
+		}
+
+		col := make([]driver.Value, rows)
+		if field != nil && !db.DryRun {
+			ids, err := nextSequenceValues(db, field.TagSettings["SEQUENCE"], rows)
+			if err != nil {
+				db.AddError(err)
+				return
+			}
+			for i := 0; i < rows; i++ {
+				rv := reflect.Indirect(stmt.ReflectValue.Index(i))
+				if err := field.Set(stmt.Context, rv, ids[i]); err != nil {
+					db.AddError(err)
+					return
+				}
+				col[i] = ids[i]
+			}
+		} else {
+			for i := 0; i < rows; i++ {
+				col[i] = values.Values[i][j]
+				if valuer, ok := col[i].(driver.Valuer); ok {
+					v, err := valuer.Value()
+					if err != nil {
+						db.AddError(err)
+						return
+					}
+					col[i] = v
+				}
+			}
+		}
+
+		columns = append(columns, col)
+		placeholders = append(placeholders, fmt.Sprintf(":%d", len(columns)))
+	}
+
+	stmt.SQL.Reset()
+	stmt.SQL.WriteString("INSERT INTO ")
+	stmt.WriteQuoted(clause.Table{Name: stmt.Table})
+	stmt.SQL.WriteString(" (")
+	for idx, name := range columnNames {
+		if idx > 0 {
+			stmt.SQL.WriteByte(',')
+		}
+		stmt.WriteQuoted(name)
+	}
+	stmt.SQL.WriteString(") VALUES (")
+	stmt.SQL.WriteString(strings.Join(placeholders, ","))
+	stmt.SQL.WriteString(")")
+
+	stmt.Vars = make([]interface{}, 0, len(columns))
+	for _, col := range columns {
+		stmt.Vars = append(stmt.Vars, col)
+	}
+
+	if db.DryRun || db.Error != nil {
+		return
+	}
+
+	result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), &arrayBinding{Rows: rows, Columns: columns})
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	db.RowsAffected, _ = result.RowsAffected()
+}
+
+// nextSequenceValues fetches the next `count` values of the sequence
+func nextSequenceValues(db *gorm.DB, seqName string, count int) ([]int64, error) {
+	ids := make([]int64, 0, count)
+	rows, err := db.Statement.ConnPool.QueryContext(db.Statement.Context,
+		fmt.Sprintf("SELECT %s.NEXTVAL FROM DUAL CONNECT BY LEVEL <= :1", db.Statement.Quote(seqName)), count)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) != count {
+		return nil, fmt.Errorf("%d values fetched from sequence %s, %d expected", len(ids), seqName, count)
+	}
+	return ids, rows.Err()
+}
@@ -64,7 +64,7 @@ func (dialector Dialector) getSchemaIntAndUnitType(field *schema.Field) string {
 	}
 
 	// TODO: test
-	if field.AutoIncrement && dialector.Config.supportIdentity {
+	if isIdentity(field) && dialector.Config.capabilities.Identity {
 		sqlType += " GENERATED ALWAYS as IDENTITY(START with 1 INCREMENT by 1)"
 	}
 
@@ -75,9 +75,16 @@ func (dialector Dialector) getSchemaCustomType(field *schema.Field) string {
 	sqlType := string(field.DataType)
 
 	// TODO: test
-	if field.AutoIncrement && !strings.Contains(strings.ToLower(sqlType), " auto_increment") {
+	if isIdentity(field) && !strings.Contains(strings.ToLower(sqlType), " auto_increment") {
 		sqlType += " GENERATED ALWAYS as IDENTITY(START with 1 INCREMENT by 1)"
 	}
 
 	return sqlType
 }
+
+// isIdentity returns true if the auto increment field is generated by the IDENTITY column,
+// the values of the field with the `sequence` tag are generated by its sequence instead.
+func isIdentity(field *schema.Field) bool {
+	_, isSeq := field.TagSettings["SEQUENCE"]
+	return field.AutoIncrement && !isSeq
+}
@@ -34,11 +34,17 @@ const (
 	dialectorName        string = "oracle"
 	ctxKeyIsBatchInsert  string = "is_batch_insert"
 	ctxKeyNextFieldIndex string = "next_field_index"
+	ctxKeyRowsAffected   string = "rows_affected"
+	ctxKeyReturning      string = "returning"
 )
 
 var (
 	// CreateClauses create clauses
 	CreateClauses = []string{"INSERT", "VALUES", "ON CONFLICT", "RETURNING"}
+	// UpdateClauses update clauses
+	UpdateClauses = []string{"UPDATE", "SET", "WHERE", "RETURNING"}
+	// DeleteClauses delete clauses
+	DeleteClauses = []string{"DELETE", "FROM", "WHERE", "RETURNING"}
 
 	// defaultDatetimePrecision = 3
 )
@@ -52,21 +58,154 @@ func (dialector Dialector) Name() string {
 	return dialectorName
 }
 
-// Initialize implements gorm.Dialector interface
+// Initialize implements gorm.Dialector interface, the detected server version
+// and capabilities are kept in the shared Config, so the Dialector can be passed by value.
 func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 	ctx := context.Background()
 
+	if dialector.Config.envErr != nil {
+		return dialector.Config.envErr
+	}
+
 	// register callbacks
-	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{
+	callbackConfig := &callbacks.Config{
 		CreateClauses: CreateClauses,
-	})
+		UpdateClauses: UpdateClauses,
+		DeleteClauses: DeleteClauses,
+	}
+	callbacks.RegisterDefaultCallbacks(db, callbackConfig)
+	if err = db.Callback().Create().Replace("gorm:create", dialector.Create(callbackConfig)); err != nil {
+		return errors.Wrapf(err, "replace callback failed")
+	}
+
+	if err = db.Callback().Create().After("gorm:create").Register("oracle:rows_affected", callbackRowsAffected); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Update().After("gorm:update").Register("oracle:returning", callbackReturning); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Update().After("oracle:returning").Register("oracle:rows_affected", callbackRowsAffected); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Delete().After("gorm:delete").Register("oracle:returning", callbackReturning); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Delete().After("oracle:returning").Register("oracle:rows_affected", callbackRowsAffected); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+
+	if err = db.Callback().Query().Replace("gorm:query", callbackQuery); err != nil {
+		return errors.Wrapf(err, "replace callback failed")
+	}
+	if err = db.Callback().Query().Before("gorm:query").Register("oracle:named_binds", callbackNamedBinds); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Row().Before("gorm:row").Register("oracle:named_binds", callbackNamedBinds); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Raw().Before("gorm:raw").Register("oracle:named_binds", callbackNamedBinds); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+
+	// sets the application context around the statements, see: WithAppContext
+	// the default transaction of Create/Update/Delete is begun on the pinned connection, so the
+	// callbacks wrap all of the others, Before("gorm:begin_transaction") is not kept by the sorting
+	// of gorm when gorm:create is replaced.
+	if _, err = dialector.appContextPackage(); err != nil {
+		return err
+	}
+	if err = db.Callback().Create().Before("*").Register("oracle:set_app_context", dialector.callbackSetAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Create().After("*").Register("oracle:clear_app_context", dialector.callbackClearAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Update().Before("*").Register("oracle:set_app_context", dialector.callbackSetAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Update().After("*").Register("oracle:clear_app_context", dialector.callbackClearAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Delete().Before("*").Register("oracle:set_app_context", dialector.callbackSetAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Delete().After("*").Register("oracle:clear_app_context", dialector.callbackClearAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Query().Before("gorm:query").Register("oracle:set_app_context", dialector.callbackSetAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Query().After("gorm:after_query").Register("oracle:clear_app_context", dialector.callbackClearAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Row().Before("gorm:row").Register("oracle:set_app_context", dialector.callbackSetAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Row().After("gorm:row").Register("oracle:clear_app_context", dialector.callbackClearAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Raw().Before("gorm:raw").Register("oracle:set_app_context", dialector.callbackSetAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Raw().After("gorm:raw").Register("oracle:clear_app_context", dialector.callbackClearAppContext); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+
+	// translates the errors of driver to oracle.Error
+	if err = db.Callback().Create().After("gorm:create").Register("oracle:translate_error", callbackTranslateError); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Query().After("gorm:query").Register("oracle:translate_error", callbackTranslateError); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Update().After("gorm:update").Register("oracle:translate_error", callbackTranslateError); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Delete().After("gorm:delete").Register("oracle:translate_error", callbackTranslateError); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Row().After("gorm:row").Register("oracle:translate_error", callbackTranslateError); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+	if err = db.Callback().Raw().After("gorm:raw").Register("oracle:translate_error", callbackTranslateError); err != nil {
+		return errors.Wrapf(err, "register callback failed")
+	}
+
+	// the uppercase names match the data dictionary of Oracle, unless the naming
+	// strategy is specified by gorm.Config.
+	if ns, ok := db.NamingStrategy.(schema.NamingStrategy); ok && ns == (schema.NamingStrategy{}) && !dialector.DontUseNamingStrategy {
+		db.NamingStrategy = NamingStrategy{}
+	}
 
 	if dialector.DriverName == "" {
 		dialector.DriverName = dialectorName
 	}
 
+	// the session settings are applied to every new connection of the pool
+	statements, err := dialector.Config.InitStatements()
+	if err != nil {
+		return err
+	}
+
 	if dialector.Conn != nil {
+		if len(statements) > 0 {
+			return errors.New("the session settings are not applied to Config.Conn, set them on the connections of it instead")
+		}
 		db.ConnPool = dialector.Conn
+	} else if dialector.DriverName == dialectorName {
+		// wraps the go-ora driver to enable the features like array binding
+		c, err := newConnector(dialector.DSN, statements)
+		if err != nil {
+			return errors.Wrapf(err, "newConnector failed")
+		}
+		db.ConnPool = sql.OpenDB(c)
+		dialector.Config.wrapped = true
+	} else if len(statements) > 0 {
+		c, err := newDriverConnector(dialector.DriverName, dialector.DSN, statements)
+		if err != nil {
+			return errors.Wrapf(err, "newDriverConnector failed")
+		}
+		db.ConnPool = sql.OpenDB(c)
 	} else {
 		db.ConnPool, err = sql.Open(dialector.DriverName, dialector.DSN)
 		if err != nil {
@@ -74,23 +213,38 @@ func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 		}
 	}
 
+	if sqlDB, ok := db.ConnPool.(*sql.DB); ok {
+		dialector.Config.applyConnPool(sqlDB)
+	}
+
 	if !dialector.Config.SkipInitializeWithVersion {
-		err = db.ConnPool.QueryRowContext(ctx, "SELECT * FROM v$version	WHERE banner LIKE 'Oracle%'").Scan(&dialector.ServerVersion)
+		err = db.ConnPool.QueryRowContext(ctx, "SELECT BANNER FROM v$version WHERE BANNER LIKE 'Oracle%'").Scan(&dialector.ServerVersion)
 		if err != nil {
 			return errors.Wrapf(err, "db.ConnPool.QueryRowContext failed")
 		}
 
-		// https://en.wikipedia.org/wiki/Oracle_Database
-		// TEST：tested: Oracle Database 11g Enterprise Edition Release 11.2.0.4.0 - 64bit Production
-		if strings.Contains(dialector.ServerVersion, "12c") ||
-			strings.Contains(dialector.ServerVersion, "18c") ||
-			strings.Contains(dialector.ServerVersion, "19c") ||
-			strings.Contains(dialector.ServerVersion, "21c") {
-			dialector.Config.supportIdentity = true
-			dialector.Config.supportOffsetFetch = true
+		// the banner of 18c and later only contains the release, e.g.: Release 19.0.0.0.0,
+		// the full version is in VERSION_FULL, e.g.: 19.3.0.0.0
+		var fullVersion string
+		if e := db.ConnPool.QueryRowContext(ctx, "SELECT VERSION_FULL FROM PRODUCT_COMPONENT_VERSION WHERE PRODUCT LIKE 'Oracle%'").Scan(&fullVersion); e == nil && fullVersion != "" {
+			dialector.ServerVersion += " Version " + fullVersion
 		}
+
+		// v$parameter may not be granted, the COMPATIBLE parameter is ignored then
+		_ = db.ConnPool.QueryRowContext(ctx, "SELECT VALUE FROM v$parameter WHERE NAME = 'compatible'").Scan(&dialector.Config.compatible)
 	}
 
+	// the settings are verified even if the version is configured, e.g.: by GORM_ORACLE_SKIP_VERSION_CHECK
+	if len(statements) > 0 && !db.DryRun && !dialector.Config.SkipSessionVerification {
+		if err = dialector.Config.verifySession(ctx, db.ConnPool); err != nil {
+			return errors.Wrapf(err, "verify session failed")
+		}
+	}
+
+	// features are decided by the detected or configured server version,
+	// so that the LIMIT clause can be rewritten by ROWNUM for Oracle 11g.
+	dialector.Config.applyServerVersion()
+
 	for k, v := range dialector.ClauseBuilders() {
 		db.ClauseBuilders[k] = v
 	}
@@ -98,6 +252,31 @@ func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 	return
 }
 
+// wrapsConnPool returns true if the statements of pool are executed by the wrapped go-ora connection,
+// which is required by the driver level features, e.g.: array binding. The pools of Config.Conn and
+// other drivers are not wrapped, neither are the prepared statements of gorm.Config.PrepareStmt.
+func (dialector Dialector) wrapsConnPool(pool gorm.ConnPool) bool {
+	if dialector.Config == nil || !dialector.Config.wrapped {
+		return false
+	}
+	switch pool.(type) {
+	case *sql.DB, *sql.Tx, *sql.Conn:
+		return true
+	}
+	return false
+}
+
+// Version returns the version of the server, see: Config.ServerVersion
+func (dialector Dialector) Version() Version {
+	return dialector.Config.version
+}
+
+// ServerCapabilities returns the features supported by the server, which are decided by
+// Config.Capabilities if it is specified
+func (dialector Dialector) ServerCapabilities() Capabilities {
+	return dialector.Config.capabilities
+}
+
 // Migrator implements gorm.Dialector interface
 func (dialector Dialector) Migrator(db *gorm.DB) gorm.Migrator {
 	return Migrator{
@@ -141,7 +320,11 @@ func (dialector Dialector) DefaultValueOf(field *schema.Field) clause.Expression
 func (dialector Dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
 
 	isInsert := utils.Contains(stmt.BuildClauses, "INSERT")
-	if !isInsert {
+	_, isMerge := isMerge(stmt)
+	isBatchInsert, _ := stmt.Context.Value(ctxKeyIsBatchInsert).(bool)
+	isReturning, _ := hasReturning(stmt)
+	// parameters of MERGE and the PL/SQL block of batch insert returning are bound by the order
+	if !isInsert || isMerge || (isBatchInsert && isReturning) {
 		writer.WriteString(fmt.Sprintf(":p%d", len(stmt.Vars)))
 		return
 	}
@@ -149,22 +332,58 @@ func (dialector Dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement,
 	writer.WriteString(dialector.bindVarParameter(stmt))
 }
 
-// QuoteTo implements gorm.Dialector interface
-func (dialector Dialector) QuoteTo(writer clause.Writer, str string) {
-	writer.WriteString(str)
-}
-
 // Explain implements gorm.Dialector interface
 func (dialector Dialector) Explain(sql string, vars ...interface{}) string {
 	return logger.ExplainSQL(sql, nil, `'`, vars...)
 }
 
-// SavePoint implements gorm.SavePointerDialectorInterface interface
+// SavePoint implements gorm.SavePointerDialectorInterface interface, the name is normalized
+// by savePointName, so that the names generated by gorm for the nested transactions, e.g.:
+// sp0xc0001234a0, and the names of users can be used safely.
 func (dialector Dialector) SavePoint(tx *gorm.DB, name string) error {
+	name, err := dialector.savePointName(name)
+	if err != nil {
+		return err
+	}
 	return tx.Exec("SAVEPOINT " + name).Error
 }
 
 // RollbackTo implements gorm.SavePointerDialectorInterface interface
 func (dialector Dialector) RollbackTo(tx *gorm.DB, name string) error {
+	name, err := dialector.savePointName(name)
+	if err != nil {
+		return err
+	}
 	return tx.Exec("ROLLBACK TO SAVEPOINT " + name).Error
 }
+
+// ReleaseSavePoint releases the savepoint in RELEASE SAVEPOINT semantics, which is not supported
+// by Oracle. Nothing is executed since the savepoints are released when the transaction is committed
+// or rolled back, and the savepoint is replaced by the one created later with the same name, so
+// only the name is validated.
+func (dialector Dialector) ReleaseSavePoint(tx *gorm.DB, name string) error {
+	_, err := dialector.savePointName(name)
+	return err
+}
+
+// savePointName returns the quoted name of savepoint, which is shortened by the identifier
+// length limit of the server. Any name without double quotation marks is valid.
+func (dialector Dialector) savePointName(name string) (string, error) {
+	if isQuoted(name) {
+		name = name[1 : len(name)-1]
+	}
+	if name == "" || strings.ContainsAny(name, "\"\x00") {
+		return "", fmt.Errorf("invalid savepoint name %q", name)
+	}
+	return `"` + ShortenIdentifier(name, dialector.IdentifierMaxLength()) + `"`, nil
+}
+
+// callbackRowsAffected sets the real RowsAffected which is returned by the PL/SQL block,
+// since the gorm.Scan resets it to the count of rows returned.
+func callbackRowsAffected(db *gorm.DB) {
+	if db.Error == nil && !db.DryRun {
+		if rowsAffected, ok := db.Statement.Context.Value(ctxKeyRowsAffected).(*int64); ok {
+			db.RowsAffected = *rowsAffected
+		}
+	}
+}
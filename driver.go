@@ -0,0 +1,271 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+
+	go_ora "github.com/sijms/go-ora/v2"
+)
+
+// connector wraps the connector of go-ora, so that the driver level features
+// which are not reachable by database/sql can be used, e.g.: array binding.
+type connector struct {
+	driver.Connector
+
+	// statements are executed on every new connection, see: Config.InitStatements
+	statements []string
+}
+
+func newConnector(dsn string, statements []string) (driver.Connector, error) {
+	c, err := (&go_ora.OracleDriver{}).OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &connector{Connector: c, statements: statements}, nil
+}
+
+// newDriverConnector wraps the connector of the driver registered as driverName,
+// so that the statements are executed on every new connection of other drivers.
+func newDriverConnector(driverName, dsn string, statements []string) (driver.Connector, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	drv := db.Driver()
+	_ = db.Close()
+
+	var c driver.Connector = dsnConnector{dsn: dsn, driver: drv}
+	if dc, ok := drv.(driver.DriverContext); ok {
+		if c, err = dc.OpenConnector(dsn); err != nil {
+			return nil, err
+		}
+	}
+	return &connector{Connector: c, statements: statements}, nil
+}
+
+// Connect implements driver.Connector interface
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	dc, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = execSession(ctx, dc, c.statements); err != nil {
+		_ = dc.Close()
+		return nil, err
+	}
+
+	oc, ok := dc.(*go_ora.Connection)
+	if !ok {
+		return dc, nil
+	}
+	return &conn{Connection: oc}, nil
+}
+
+// conn wraps *go_ora.Connection, all the interfaces implemented by go-ora are promoted.
+type conn struct {
+	*go_ora.Connection
+
+	// broken is true if the connection is lost, it will be discarded from the pool.
+	broken bool
+
+	// schema is CURRENT_SCHEMA switched by WithSchema, empty if it is not switched
+	schema string
+	// defaultSchema is the quoted CURRENT_SCHEMA before switched, which is restored by ResetSession
+	defaultSchema string
+
+	// info is the SessionInfo set to the session by the last statement
+	info SessionInfo
+}
+
+// ExecContext implements driver.ExecerContext interface
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.prepareSession(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(args) == 1 {
+		if binding, ok := args[0].Value.(*arrayBinding); ok {
+			return c.bulkInsert(ctx, query, binding)
+		}
+	}
+
+	result, err := c.Connection.ExecContext(ctx, query, args)
+	return result, c.check(err)
+}
+
+// bulkInsert executes the insert by array binding. go-ora does not accept ctx for it, and its
+// connection is not safe for concurrent use, so ctx is checked before the insert, and the connection
+// is discarded from the pool if ctx is done before the insert is finished, the uncommitted rows are
+// rolled back when the session is closed.
+func (c *conn) bulkInsert(ctx context.Context, query string, binding *arrayBinding) (driver.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result, err := c.Connection.BulkInsert(query, binding.Rows, binding.Columns...)
+	if ctx.Err() != nil {
+		c.broken = true
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, c.check(err)
+	}
+	return result, nil
+}
+
+// QueryContext implements driver.QueryerContext interface
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.prepareSession(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.Connection.QueryContext(ctx, query, args)
+	if err != nil {
+		return rows, c.check(err)
+	}
+
+	// restores the column aliases shortened by QuoteTo, see: Dialector.keepColumnAliases
+	if aliases, ok := ctx.Value(ctxKeyColumnAliases).(map[string]string); ok {
+		if dataSet, ok := rows.(*go_ora.DataSet); ok {
+			return &aliasedRows{DataSet: dataSet, aliases: aliases}, nil
+		}
+	}
+	return rows, nil
+}
+
+// PrepareContext implements driver.ConnPrepareContext interface
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := c.prepareSession(ctx); err != nil {
+		return nil, err
+	}
+
+	stmt, err := c.Connection.PrepareContext(ctx, query)
+	return stmt, c.check(err)
+}
+
+// BeginTx implements driver.ConnBeginTx interface
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if err := c.prepareSession(ctx); err != nil {
+		return nil, err
+	}
+
+	// the options are not supported by go-ora, they are set by SET TRANSACTION instead
+	stmt, err := TransactionStatement(ctx, &sql.TxOptions{Isolation: sql.IsolationLevel(opts.Isolation), ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := c.Connection.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		return nil, c.check(err)
+	}
+
+	// SET TRANSACTION must be the first statement of the transaction
+	if stmt != "" {
+		if _, err = c.Connection.ExecContext(ctx, stmt, nil); err != nil {
+			_ = tx.Rollback()
+			return nil, c.check(err)
+		}
+	}
+	return &transaction{Tx: tx, conn: c}, nil
+}
+
+// transaction wraps the transaction of go-ora to translate the errors of Commit and Rollback,
+// e.g.: the violation of deferred constraints raised by Commit.
+type transaction struct {
+	driver.Tx
+	conn *conn
+}
+
+// Commit implements driver.Tx interface
+func (tx *transaction) Commit() error {
+	return translateError(tx.conn.check(tx.Tx.Commit()))
+}
+
+// Rollback implements driver.Tx interface
+func (tx *transaction) Rollback() error {
+	return translateError(tx.conn.check(tx.Tx.Rollback()))
+}
+
+// Ping implements driver.Pinger interface
+func (c *conn) Ping(ctx context.Context) error {
+	return c.check(c.Connection.Ping(ctx))
+}
+
+// IsValid implements driver.Validator interface, the broken connection
+// is discarded instead of being returned to the pool.
+func (c *conn) IsValid() bool {
+	return !c.broken
+}
+
+// ResetSession implements driver.SessionResetter interface, it is called before the connection
+// is reused from the pool, CURRENT_SCHEMA switched by WithSchema is restored.
+func (c *conn) ResetSession(ctx context.Context) error {
+	if c.broken {
+		return driver.ErrBadConn
+	}
+	if err := c.restoreSchema(ctx); err != nil {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+// prepareSession applies the session settings carried by ctx before the statements,
+// see: WithSchema and WithSessionInfo
+func (c *conn) prepareSession(ctx context.Context) error {
+	if err := c.switchSchema(ctx); err != nil {
+		return err
+	}
+	return c.applySessionInfo(ctx)
+}
+
+// check marks the connection as broken if err is caused by the lost connection
+func (c *conn) check(err error) error {
+	if e, ok := AsError(err); ok && e.ConnectionLost() {
+		c.broken = true
+	}
+	return err
+}
+
+// aliasedRows wraps *go_ora.DataSet to restore the column names
+type aliasedRows struct {
+	*go_ora.DataSet
+	aliases map[string]string
+}
+
+// Columns implements driver.Rows interface
+func (r *aliasedRows) Columns() []string {
+	columns := r.DataSet.Columns()
+	for idx, column := range columns {
+		if alias, ok := r.aliases[strings.ToUpper(column)]; ok {
+			columns[idx] = alias
+		}
+	}
+	return columns
+}
+
+// dsnConnector is the connector of the driver which does not implement driver.DriverContext
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+// Connect implements driver.Connector interface
+func (c dsnConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+// Driver implements driver.Connector interface
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// arrayBinding is the only argument to execute a statement by array binding (array DML),
+// each column is bound by a slice of values.
+type arrayBinding struct {
+	Rows    int
+	Columns [][]driver.Value
+}
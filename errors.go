@@ -0,0 +1,176 @@
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/sijms/go-ora/v2/network"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrDuplicatedKey occurs when violating a unique constraint, ORA-00001
+	ErrDuplicatedKey = errors.New("duplicated key not allowed")
+	// ErrForeignKeyViolated occurs when violating a foreign key constraint, ORA-02291 and ORA-02292
+	ErrForeignKeyViolated = errors.New("violates foreign key constraint")
+	// ErrCheckConstraintViolated occurs when violating a check constraint, ORA-02290
+	ErrCheckConstraintViolated = errors.New("violates check constraint")
+)
+
+// the error codes of Oracle
+// See: https://docs.oracle.com/en/database/oracle/oracle-database/19/errmg/
+const (
+	codeUniqueConstraint     = 1
+	codeResourceBusy         = 54
+	codeDeadlock             = 60
+	codeInitOrShutdown       = 1033
+	codeNotAvailable         = 1034
+	codeImmediateShutdown    = 1089
+	codeNoDataFound          = 1403
+	codeCheckConstraint      = 2290
+	codeParentKeyNotFound    = 2291
+	codeChildRecordFound     = 2292
+	codeEndOfFile            = 3113
+	codeNotConnected         = 3114
+	codeLostContact          = 3135
+	codePackageStateDiscard  = 4068
+	codeConnectionNotOpen    = 6413
+	codeSerializeAccess      = 8177
+	codeConnectTimeout       = 12170
+	codeNoListener           = 12541
+	codeTNSLostContact       = 12547
+	codeTNSPacketWriteFailed = 12571
+	codeCannotSafelyReplay   = 25408
+	codeResourceBusyTimeout  = 30006
+)
+
+var (
+	// ORA-00001: unique constraint (APP.PK_CUSTOMERS) violated
+	errorCodePattern = regexp.MustCompile(`ORA-(\d{5})`)
+	// the constraint name in the parentheses
+	errorConstraintPattern = regexp.MustCompile(`constraint \(([^()]+)\)`)
+)
+
+// Error is the error returned by Oracle, which is translated from the error of driver.
+//
+//	var oraErr *oracle.Error
+//	if errors.As(err, &oraErr) && oraErr.Code == 1 { ... }
+//	if errors.Is(err, oracle.ErrDuplicatedKey) { ... }
+type Error struct {
+	// Code is the number of ORA- error, e.g.: 1 for ORA-00001
+	Code int
+	// Message is the message of the error
+	Message string
+	// Constraint is the violated constraint name with owner, e.g.: APP.PK_CUSTOMERS
+	Constraint string
+
+	err error
+}
+
+// Error implements error interface, the message of the original error is kept.
+func (e *Error) Error() string {
+	if e.err != nil {
+		return e.err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the original error of driver
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Is makes errors.Is to match the sentinel errors by the code
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrDuplicatedKey:
+		return e.Code == codeUniqueConstraint
+	case ErrForeignKeyViolated:
+		return e.Code == codeParentKeyNotFound || e.Code == codeChildRecordFound
+	case ErrCheckConstraintViolated:
+		return e.Code == codeCheckConstraint
+	case gorm.ErrRecordNotFound:
+		return e.Code == codeNoDataFound
+	}
+	return false
+}
+
+// Retryable returns true if the statement or transaction is worth retrying,
+// e.g.: deadlock, serialization failure, resource busy and connection lost.
+func (e *Error) Retryable() bool {
+	switch e.Code {
+	case codeDeadlock, codeSerializeAccess, codeResourceBusy, codeResourceBusyTimeout, codePackageStateDiscard:
+		return true
+	}
+	return e.ConnectionLost()
+}
+
+// ConnectionLost returns true if the connection is broken or the server is not available.
+func (e *Error) ConnectionLost() bool {
+	switch e.Code {
+	case codeEndOfFile, codeNotConnected, codeLostContact, codeConnectionNotOpen,
+		codeConnectTimeout, codeNoListener, codeTNSLostContact, codeTNSPacketWriteFailed,
+		codeCannotSafelyReplay, codeInitOrShutdown, codeNotAvailable, codeImmediateShutdown:
+		return true
+	}
+	return false
+}
+
+// AsError returns the Error of Oracle if err is or wraps an ORA- error.
+func AsError(err error) (*Error, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+
+	var (
+		code    int
+		message string
+	)
+	var oraErr *network.OracleError
+	if errors.As(err, &oraErr) {
+		code, message = oraErr.ErrCode, oraErr.ErrMsg
+	} else if matches := errorCodePattern.FindStringSubmatch(err.Error()); len(matches) == 2 {
+		// the errors of other drivers
+		code, _ = strconv.Atoi(matches[1])
+		message = err.Error()
+	} else {
+		return nil, false
+	}
+
+	if message == "" {
+		message = fmt.Sprintf("ORA-%05d", code)
+	}
+	e = &Error{Code: code, Message: message, err: err}
+	if matches := errorConstraintPattern.FindStringSubmatch(message); len(matches) == 2 {
+		e.Constraint = matches[1]
+	}
+	return e, true
+}
+
+// callbackTranslateError translates the error of driver to Error,
+// so that it can be checked by errors.Is with the sentinel errors.
+func callbackTranslateError(db *gorm.DB) {
+	if db.Error != nil {
+		db.Error = translateError(db.Error)
+	}
+}
+
+// translateError translates err to Error if it is an ORA- error, the errors
+// already translated or wrapping the translated ones are returned as they are.
+func translateError(err error) error {
+	var translated *Error
+	if err == nil || errors.As(err, &translated) {
+		return err
+	}
+	if e, ok := AsError(err); ok {
+		return e
+	}
+	return err
+}
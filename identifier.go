@@ -0,0 +1,113 @@
+package oracle
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// identifierMaxLength is the max length in bytes of identifiers before Oracle 12.2
+	identifierMaxLength = 30
+	// longIdentifierMaxLength is the max length in bytes of identifiers since Oracle 12.2
+	// See: https://docs.oracle.com/en/database/oracle/oracle-database/12.2/newft/new-features.html#GUID-64283AD6-0939-47B0-856E-5E9255D7246B
+	longIdentifierMaxLength = 128
+
+	// identifierHashLength is the length of the hash suffix of the shortened identifiers
+	identifierHashLength = 8
+
+	ctxKeyColumnAliases string = "column_aliases"
+)
+
+// IdentifierMaxLength returns the max length in bytes of identifiers of the server version,
+// the longer identifiers are shortened by ShortenIdentifier to avoid ORA-00972.
+func (dialector Dialector) IdentifierMaxLength() int {
+	if dialector.Config != nil && dialector.Config.capabilities.LongIdentifier {
+		return longIdentifierMaxLength
+	}
+	return identifierMaxLength
+}
+
+// ShortenIdentifier shortens the identifier longer than maxLength bytes deterministically,
+// by truncating it and appending the hash of the whole name, e.g.:
+//
+//	IDX_CUSTOMER_ORDERS_CREATED_TIME => IDX_CUSTOMER_ORDERS__1A2B3C4D
+//
+// The quoted identifier is shortened inside the quotes.
+func ShortenIdentifier(name string, maxLength int) string {
+	if isQuoted(name) {
+		return `"` + ShortenIdentifier(name[1:len(name)-1], maxLength) + `"`
+	}
+
+	if len(name) <= maxLength || maxLength <= identifierHashLength+1 {
+		return name
+	}
+
+	h := sha1.New()
+	h.Write([]byte(name))
+	hash := strings.ToUpper(hex.EncodeToString(h.Sum(nil))[:identifierHashLength])
+
+	// do not break the multi-byte characters
+	prefix := name[:maxLength-identifierHashLength-1]
+	for len(prefix) > 0 && !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	return prefix + "_" + hash
+}
+
+// shortenIdentifier shortens the identifier by the limit of the dialector of db
+func shortenIdentifier(db *gorm.DB, name string) string {
+	if dialector, ok := dialectorOf(db); ok {
+		return ShortenIdentifier(name, dialector.IdentifierMaxLength())
+	}
+	return name
+}
+
+// dialectorOf returns the Dialector of db, which is passed by pointer by Open and New, or by value
+func dialectorOf(db *gorm.DB) (Dialector, bool) {
+	switch dialector := db.Dialector.(type) {
+	case *Dialector:
+		return *dialector, dialector.Config != nil
+	case Dialector:
+		return dialector, dialector.Config != nil
+	}
+	return Dialector{}, false
+}
+
+// keepColumnAliases keeps the aliases of the select columns which will be shortened by
+// QuoteTo, e.g.: the aliases of joined columns `Department__DEPARTMENT_NAME`, so that
+// the column names of the result set are restored by the driver, see: conn.QueryContext.
+//
+// The aliases can not be restored by the pools which are not wrapped, e.g.: Config.Conn or
+// gorm.Config.PrepareStmt, an error is added instead of returning the shortened column names.
+func (dialector Dialector) keepColumnAliases(stmt *gorm.Statement, c clause.Clause) {
+	sel, ok := c.Expression.(clause.Select)
+	if !ok {
+		return
+	}
+
+	maxLength := dialector.IdentifierMaxLength()
+	aliases := map[string]string{}
+	for _, column := range sel.Columns {
+		if column.Raw || column.Alias == "" {
+			continue
+		}
+		if alias := ShortenIdentifier(column.Alias, maxLength); alias != column.Alias {
+			aliases[strings.ToUpper(dictionaryName(alias))] = column.Alias
+		}
+	}
+
+	if len(aliases) > 0 {
+		if !dialector.wrapsConnPool(stmt.ConnPool) {
+			stmt.AddError(fmt.Errorf("%d column aliases exceed %d bytes, which can not be restored by the connection pool %T", len(aliases), maxLength, stmt.ConnPool))
+			return
+		}
+		stmt.Context = context.WithValue(stmt.Context, ctxKeyColumnAliases, aliases)
+	}
+}
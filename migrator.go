@@ -11,48 +11,84 @@ import (
 	"gorm.io/gorm/schema"
 )
 
+// indexSql reads the indexes of the table with their columns in order, the expressions
+// of the function-based indexes, and the primary key or unique constraints using them.
 const indexSql = `
 SELECT
-	TABLE_NAME,
-	COLUMN_NAME,
-	INDEX_NAME,
-	NON_UNIQUE 
+	i.TABLE_NAME,
+	i.INDEX_NAME,
+	i.INDEX_TYPE,
+	i.UNIQUENESS,
+	c.COLUMN_NAME,
+	c.COLUMN_POSITION,
+	e.COLUMN_EXPRESSION,
+	(
+		SELECT MIN(k.CONSTRAINT_TYPE) FROM ALL_CONSTRAINTS k
+		WHERE k.OWNER = i.TABLE_OWNER AND k.TABLE_NAME = i.TABLE_NAME
+			AND k.INDEX_OWNER = i.OWNER AND k.INDEX_NAME = i.INDEX_NAME
+			AND k.CONSTRAINT_TYPE IN ('P', 'U')
+	) AS CONSTRAINT_TYPE
 FROM
-	information_schema.STATISTICS 
+	ALL_INDEXES i
+	JOIN ALL_IND_COLUMNS c ON c.INDEX_OWNER = i.OWNER AND c.INDEX_NAME = i.INDEX_NAME
+	LEFT JOIN ALL_IND_EXPRESSIONS e ON e.INDEX_OWNER = c.INDEX_OWNER AND e.INDEX_NAME = c.INDEX_NAME
+		AND e.COLUMN_POSITION = c.COLUMN_POSITION
 WHERE
-	TABLE_SCHEMA = ? 
-	AND TABLE_NAME = ? 
+	i.TABLE_OWNER = ?
+	AND i.TABLE_NAME = ?
 ORDER BY
-	INDEX_NAME,
-	SEQ_IN_INDEX`
+	i.INDEX_NAME,
+	c.COLUMN_POSITION`
 
-type Migrator struct {
-	migrator.Migrator
-	Dialector
-}
-
-func (m Migrator) FullDataTypeOf(field *schema.Field) clause.Expr {
-	expr := m.Migrator.FullDataTypeOf(field)
+// columnTypeSql reads the visible columns of the table in order, with the primary key or
+// single-column unique constraints using them, and the generation type of identity columns.
+const columnTypeSql = `
+SELECT
+	c.COLUMN_NAME,
+	c.DATA_DEFAULT,
+	c.NULLABLE,
+	c.DATA_TYPE,
+	c.DATA_LENGTH,
+	c.CHAR_LENGTH,
+	c.CHAR_USED,
+	c.DATA_PRECISION,
+	c.DATA_SCALE,
+	m.COMMENTS,
+	(
+		SELECT MIN(k.CONSTRAINT_TYPE) FROM ALL_CONSTRAINTS k
+		JOIN ALL_CONS_COLUMNS kc ON kc.OWNER = k.OWNER AND kc.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+		WHERE k.OWNER = c.OWNER AND k.TABLE_NAME = c.TABLE_NAME AND kc.COLUMN_NAME = c.COLUMN_NAME
+			AND (k.CONSTRAINT_TYPE = 'P' OR k.CONSTRAINT_TYPE = 'U' AND NOT EXISTS (
+				SELECT 1 FROM ALL_CONS_COLUMNS o
+				WHERE o.OWNER = k.OWNER AND o.CONSTRAINT_NAME = k.CONSTRAINT_NAME AND o.COLUMN_NAME <> c.COLUMN_NAME
+			))
+	) AS CONSTRAINT_TYPE,
+	%s AS GENERATION_TYPE
+FROM
+	ALL_TAB_COLS c
+	LEFT JOIN ALL_COL_COMMENTS m ON m.OWNER = c.OWNER AND m.TABLE_NAME = c.TABLE_NAME AND m.COLUMN_NAME = c.COLUMN_NAME
+WHERE
+	c.OWNER = ?
+	AND c.TABLE_NAME = ?
+	AND c.HIDDEN_COLUMN = 'NO'%s
+ORDER BY
+	c.COLUMN_ID`
 
-	// https://docs.oracle.com/cd/B19306_01/server.102/b14200/statements_4009.htm
-	if value, ok := field.TagSettings["COMMENT"]; ok {
-		panic("not implement")
-		expr.SQL += " COMMENT " + m.Dialector.Explain("?", value)
-	}
+// identityColumnSql reads the generation type of the identity column, e.g.: ALWAYS, BY DEFAULT
+const identityColumnSql = `(
+		SELECT t.GENERATION_TYPE FROM ALL_TAB_IDENTITY_COLS t
+		WHERE t.OWNER = c.OWNER AND t.TABLE_NAME = c.TABLE_NAME AND t.COLUMN_NAME = c.COLUMN_NAME
+	)`
 
-	return expr
-}
+// userGeneratedColumnSql excludes the system-generated columns which are not hidden, e.g.: the
+// columns of the extended statistics, USER_GENERATED is available since 12.1, before which these
+// columns are always hidden.
+const userGeneratedColumnSql = `
+	AND c.USER_GENERATED = 'YES'`
 
-func (m Migrator) AlterColumn(value interface{}, field string) error {
-	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		if field := stmt.Schema.LookUpField(field); field != nil {
-			return m.DB.Exec(
-				"ALTER TABLE ? MODIFY COLUMN ? ?",
-				clause.Table{Name: stmt.Table}, clause.Column{Name: field.DBName}, m.FullDataTypeOf(field),
-			).Error
-		}
-		return fmt.Errorf("failed to look up field with name: %s", field)
-	})
+type Migrator struct {
+	migrator.Migrator
+	Dialector
 }
 
 func (m Migrator) RenameColumn(value interface{}, oldName, newName string) error {
@@ -122,6 +158,45 @@ func (m Migrator) RenameIndex(value interface{}, oldName, newName string) error
 
 }
 
+// HasIndex checks the index of the table by ALL_INDEXES, the name is shortened as it is created.
+func (m Migrator) HasIndex(value interface{}, name string) bool {
+	var count int64
+	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if idx := stmt.Schema.LookIndex(name); idx != nil {
+			name = idx.Name
+		}
+
+		owner, table := m.CurrentSchema(stmt, stmt.Table)
+		return m.DB.Raw(
+			"SELECT COUNT(*) FROM ALL_INDEXES WHERE TABLE_OWNER = ? AND TABLE_NAME = ? AND INDEX_NAME = ?",
+			owner, table, dictionaryName(shortenIdentifier(m.DB, name)),
+		).Row().Scan(&count)
+	})
+
+	return count > 0
+}
+
+// HasConstraint checks the constraint by ALL_CONSTRAINTS, the name is shortened as it is created.
+func (m Migrator) HasConstraint(value interface{}, name string) bool {
+	var count int64
+	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		constraint, chk, table := m.GuessConstraintAndTable(stmt, name)
+		if constraint != nil {
+			name = constraint.Name
+		} else if chk != nil {
+			name = chk.Name
+		}
+
+		owner, table := m.CurrentSchema(stmt, table)
+		return m.DB.Raw(
+			"SELECT COUNT(*) FROM ALL_CONSTRAINTS WHERE OWNER = ? AND TABLE_NAME = ? AND CONSTRAINT_NAME = ?",
+			owner, table, dictionaryName(shortenIdentifier(m.DB, name)),
+		).Row().Scan(&count)
+	})
+
+	return count > 0
+}
+
 func (m Migrator) DropTable(values ...interface{}) error {
 	values = m.ReorderModels(values, false)
 	return m.DB.Connection(func(tx *gorm.DB) error {
@@ -147,18 +222,30 @@ func (m Migrator) DropConstraint(value interface{}, name string) error {
 	})
 }
 
-// ColumnTypes column types return columnTypes,error
+// ColumnTypes returns the columns of the table by ALL_TAB_COLS, the hidden and system-generated
+// columns, e.g.: the virtual columns of function-based indexes, are excluded. The primary key and
+// unique columns are read from ALL_CONSTRAINTS, and the identity columns from ALL_TAB_IDENTITY_COLS.
 func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
-	// https://docs.oracle.com/cd/E17952_01/mysql-8.0-en/information-schema-columns-table.html
 	// https://docs.oracle.com/en/database/oracle/oracle-database/18/refrn/DBA_TAB_COLS.html#GUID-857C32FD-AE30-4AB9-811B-AC3A7B91A04D
 	columnTypes := make([]gorm.ColumnType, 0)
 	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		var (
-			currentDatabase, table = m.CurrentSchema(stmt, stmt.Table)
-			columnTypeSQL          = "SELECT c1.COLUMN_NAME,DATA_DEFAULT,NULLABLE,DATA_TYPE,DATA_LENGTH,CONCAT( DATA_TYPE,'('||DATA_LENGTH||')')  as column_type,'' as column_key,'' as extra,c2.comments,DATA_PRECISION,DATA_SCALE "
-			rows, err              = m.DB.Session(&gorm.Session{}).Table(table).Limit(1).Rows()
-		)
+		currentDatabase, table := m.CurrentSchema(stmt, stmt.Table)
+
+		// ALL_TAB_IDENTITY_COLS and USER_GENERATED are available since 12.1
+		identityColumn, userGeneratedColumn := "NULL", ""
+		if m.Dialector.Config.capabilities.Identity {
+			identityColumn = identityColumnSql
+		}
+		if m.Dialector.Config.version.AtLeast(12, 1) {
+			userGeneratedColumn = userGeneratedColumnSql
+		}
+
+		result := make([]*tabColumn, 0)
+		if err := m.DB.Raw(fmt.Sprintf(columnTypeSql, identityColumn, userGeneratedColumn), currentDatabase, table).Scan(&result).Error; err != nil {
+			return err
+		}
 
+		rows, err := m.DB.Session(&gorm.Session{}).Table(stmt.Table).Limit(1).Rows()
 		if err != nil {
 			return err
 		}
@@ -173,63 +260,14 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 			return err
 		}
 
-		columnTypeSQL += "FROM all_tab_cols c1, all_col_comments c2 WHERE c1.table_name = ? and c1.OWNER = ? and c1.owner=c2.OWNER and c1.TABLE_NAME = c2.TABLE_NAME and c1.COLUMN_NAME=c2.COLUMN_NAME	 "
-
-		columns, rowErr := m.DB.Raw(columnTypeSQL, table, currentDatabase).Rows()
-		if rowErr != nil {
-			return rowErr
-		}
-
-		defer columns.Close()
-
-		for columns.Next() {
-			var (
-				column            migrator.ColumnType
-				datetimePrecision sql.NullInt64
-				extraValue        sql.NullString
-				columnKey         sql.NullString
-				values            = []interface{}{
-					&column.NameValue, &column.DefaultValueValue, &column.NullableValue, &column.DataTypeValue, &column.LengthValue, &column.ColumnTypeValue, &columnKey, &extraValue, &column.CommentValue, &column.DecimalSizeValue, &column.ScaleValue,
-				}
-			)
-
-			if scanErr := columns.Scan(values...); scanErr != nil {
-				return scanErr
-			}
-
-			column.PrimaryKeyValue = sql.NullBool{Bool: false, Valid: true}
-			column.UniqueValue = sql.NullBool{Bool: false, Valid: true}
-			switch columnKey.String {
-			case "PRI":
-				column.PrimaryKeyValue = sql.NullBool{Bool: true, Valid: true}
-			case "UNI":
-				column.UniqueValue = sql.NullBool{Bool: true, Valid: true}
-			}
-
-			if strings.Contains(extraValue.String, "auto_increment") {
-				column.AutoIncrementValue = sql.NullBool{Bool: true, Valid: true}
-			}
-
-			column.DefaultValueValue.String = strings.Trim(column.DefaultValueValue.String, "'")
-			if m.Dialector.DontSupportNullAsDefaultValue {
-				// rewrite mariadb default value like other version
-				if column.DefaultValueValue.Valid && column.DefaultValueValue.String == "NULL" {
-					column.DefaultValueValue.Valid = false
-					column.DefaultValueValue.String = ""
-				}
-			}
-
-			if datetimePrecision.Valid {
-				column.DecimalSizeValue = datetimePrecision
-			}
-
-			for _, c := range rawColumnTypes {
-				if c.Name() == column.NameValue.String {
-					column.SQLColumnType = c
+		for _, c := range result {
+			column := c.columnType()
+			for _, raw := range rawColumnTypes {
+				if raw.Name() == c.ColumnName {
+					column.SQLColumnType = raw
 					break
 				}
 			}
-
 			columnTypes = append(columnTypes, column)
 		}
 
@@ -239,7 +277,12 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 	return columnTypes, err
 }
 
+// CurrentDatabase returns the current schema, or the schema specified by WithSchema
 func (m Migrator) CurrentDatabase() (name string) {
+	if schema, ok := schemaOf(m.DB.Statement.Context); ok {
+		return dictionaryName(schema)
+	}
+
 	// https://docs.oracle.com/cd/B19306_01/server.102/b14200/functions165.htm
 	m.DB.Raw("SELECT SYS_CONTEXT('USERENV','CURRENT_SCHEMA') FROM DUAL").Row().Scan(&name)
 	return
@@ -247,8 +290,7 @@ func (m Migrator) CurrentDatabase() (name string) {
 
 // GetSchemaName returns current schema name
 func (m Migrator) GetSchemaName() (name string) {
-	m.DB.Raw("SELECT SYS_CONTEXT('USERENV','CURRENT_SCHEMA') FROM DUAL").Row().Scan(&name)
-	return
+	return m.CurrentDatabase()
 }
 
 func (m Migrator) GetTables() (tableList []string, err error) {
@@ -257,63 +299,197 @@ func (m Migrator) GetTables() (tableList []string, err error) {
 	return
 }
 
+// HasTable checks the table by ALL_TABLES, in the owner of the table or the current schema
+func (m Migrator) HasTable(value interface{}) bool {
+	var count int64
+
+	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		owner, table := m.CurrentSchema(stmt, stmt.Table)
+		return m.DB.Raw("SELECT COUNT(*) FROM ALL_TABLES WHERE OWNER = ? AND TABLE_NAME = ?", owner, table).Row().Scan(&count)
+	})
+
+	return count > 0
+}
+
+// GetIndexes returns the indexes of the table by ALL_INDEXES, ALL_IND_COLUMNS,
+// ALL_IND_EXPRESSIONS and ALL_CONSTRAINTS, see: Index
 func (m Migrator) GetIndexes(value interface{}) ([]gorm.Index, error) {
 	indexes := make([]gorm.Index, 0)
 	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
-
-		result := make([]*Index, 0)
-		schema, table := m.CurrentSchema(stmt, stmt.Table)
-		scanErr := m.DB.Raw(indexSql, schema, table).Scan(&result).Error
-		if scanErr != nil {
-			return scanErr
+		result := make([]*indexColumn, 0)
+		owner, table := m.CurrentSchema(stmt, stmt.Table)
+		if err := m.DB.Raw(indexSql, owner, table).Scan(&result).Error; err != nil {
+			return err
 		}
-		indexMap := groupByIndexName(result)
-
-		for _, idx := range indexMap {
-			tempIdx := &migrator.Index{
-				TableName: idx[0].TableName,
-				NameValue: idx[0].IndexName,
-				PrimaryKeyValue: sql.NullBool{
-					Bool:  idx[0].IndexName == "PRIMARY",
-					Valid: true,
-				},
-				UniqueValue: sql.NullBool{
-					Bool:  idx[0].NonUnique == 0,
-					Valid: true,
-				},
-			}
-			for _, x := range idx {
-				tempIdx.ColumnList = append(tempIdx.ColumnList, x.ColumnName)
+
+		// the columns are ordered by the index name and the position
+		var idx *Index
+		for _, column := range result {
+			if idx == nil || idx.NameValue != column.IndexName {
+				idx = &Index{
+					Index: migrator.Index{
+						TableName:       column.TableName,
+						NameValue:       column.IndexName,
+						PrimaryKeyValue: sql.NullBool{Bool: column.ConstraintType.String == "P", Valid: true},
+						UniqueValue:     sql.NullBool{Bool: column.Uniqueness == "UNIQUE", Valid: true},
+					},
+					TypeValue: column.IndexType,
+				}
+				indexes = append(indexes, idx)
 			}
-			indexes = append(indexes, tempIdx)
+			idx.ColumnList = append(idx.ColumnList, column.name())
 		}
 		return nil
 	})
 	return indexes, err
 }
 
-// Index table index info
+// Index is the index read from the data dictionary, which implements gorm.Index interface.
+// The columns of function-based indexes are the expressions, e.g.: UPPER("NAME").
 type Index struct {
-	TableName  string `gorm:"column:TABLE_NAME"`
-	ColumnName string `gorm:"column:COLUMN_NAME"`
-	IndexName  string `gorm:"column:INDEX_NAME"`
-	NonUnique  int32  `gorm:"column:NON_UNIQUE"`
+	migrator.Index
+	// TypeValue is INDEX_TYPE of ALL_INDEXES, e.g.: NORMAL, BITMAP, FUNCTION-BASED NORMAL
+	TypeValue string
+}
+
+// Type returns the type of the index, e.g.: NORMAL, BITMAP, FUNCTION-BASED NORMAL
+func (idx Index) Type() string {
+	return idx.TypeValue
+}
+
+// indexColumn is a row of indexSql
+type indexColumn struct {
+	TableName        string         `gorm:"column:TABLE_NAME"`
+	IndexName        string         `gorm:"column:INDEX_NAME"`
+	IndexType        string         `gorm:"column:INDEX_TYPE"`
+	Uniqueness       string         `gorm:"column:UNIQUENESS"`
+	ColumnName       string         `gorm:"column:COLUMN_NAME"`
+	ColumnPosition   int            `gorm:"column:COLUMN_POSITION"`
+	ColumnExpression sql.NullString `gorm:"column:COLUMN_EXPRESSION"`
+	ConstraintType   sql.NullString `gorm:"column:CONSTRAINT_TYPE"`
 }
 
-func groupByIndexName(indexList []*Index) map[string][]*Index {
-	columnIndexMap := make(map[string][]*Index, len(indexList))
-	for _, idx := range indexList {
-		columnIndexMap[idx.IndexName] = append(columnIndexMap[idx.IndexName], idx)
+// name returns the name of the column, or the expression of the function-based index,
+// the quoted column of the descending index, e.g.: "NAME", is returned as the column name.
+func (c indexColumn) name() string {
+	if !c.ColumnExpression.Valid {
+		return c.ColumnName
 	}
-	return columnIndexMap
+	expr := strings.TrimSpace(c.ColumnExpression.String)
+	if isQuoted(expr) && isIdentifier(expr) {
+		return dictionaryName(expr)
+	}
+	return expr
 }
 
-func (m Migrator) CurrentSchema(stmt *gorm.Statement, table string) (string, string) {
-	if strings.Contains(table, ".") {
-		if tables := strings.Split(table, `.`); len(tables) == 2 {
-			return tables[0], tables[1]
+// tabColumn is a row of columnTypeSql
+type tabColumn struct {
+	ColumnName     string         `gorm:"column:COLUMN_NAME"`
+	DataDefault    sql.NullString `gorm:"column:DATA_DEFAULT"`
+	Nullable       string         `gorm:"column:NULLABLE"`
+	DataType       string         `gorm:"column:DATA_TYPE"`
+	DataLength     int64          `gorm:"column:DATA_LENGTH"`
+	CharLength     int64          `gorm:"column:CHAR_LENGTH"`
+	CharUsed       sql.NullString `gorm:"column:CHAR_USED"`
+	DataPrecision  sql.NullInt64  `gorm:"column:DATA_PRECISION"`
+	DataScale      sql.NullInt64  `gorm:"column:DATA_SCALE"`
+	Comments       sql.NullString `gorm:"column:COMMENTS"`
+	ConstraintType sql.NullString `gorm:"column:CONSTRAINT_TYPE"`
+	GenerationType sql.NullString `gorm:"column:GENERATION_TYPE"`
+}
+
+// columnType converts the row to migrator.ColumnType
+func (c tabColumn) columnType() migrator.ColumnType {
+	column := migrator.ColumnType{
+		NameValue:          sql.NullString{String: c.ColumnName, Valid: true},
+		DataTypeValue:      sql.NullString{String: c.DataType, Valid: true},
+		ColumnTypeValue:    sql.NullString{String: c.fullDataType(), Valid: true},
+		LengthValue:        sql.NullInt64{Int64: c.length(), Valid: true},
+		NullableValue:      sql.NullBool{Bool: c.Nullable == "Y", Valid: true},
+		PrimaryKeyValue:    sql.NullBool{Bool: c.ConstraintType.String == "P", Valid: true},
+		UniqueValue:        sql.NullBool{Bool: c.ConstraintType.String == "U", Valid: true},
+		AutoIncrementValue: sql.NullBool{Bool: c.GenerationType.Valid, Valid: true},
+		CommentValue:       sql.NullString{String: c.Comments.String, Valid: true},
+	}
+
+	switch {
+	case strings.HasPrefix(c.DataType, "TIMESTAMP"):
+		// the precision of fractional seconds, e.g.: TIMESTAMP(6)
+		column.DecimalSizeValue = c.DataScale
+	case c.DataPrecision.Valid:
+		column.DecimalSizeValue, column.ScaleValue = c.DataPrecision, c.DataScale
+	}
+
+	// the default value of identity column is the sequence generated by Oracle, e.g.: "APP"."ISEQ$$_74570".nextval
+	if !c.GenerationType.Valid {
+		column.DefaultValueValue = normalizeDefault(c.DataDefault)
+	}
+	return column
+}
+
+// length returns the length of character and RAW columns, which is the number of characters for
+// the columns of CHAR length semantics, e.g.: VARCHAR2(100 CHAR), and 0 for the other columns.
+func (c tabColumn) length() int64 {
+	switch {
+	case c.CharUsed.String == "C":
+		return c.CharLength
+	case c.CharUsed.String == "B", c.DataType == "RAW":
+		return c.DataLength
+	}
+	return 0
+}
+
+// fullDataType returns the data type with the length, precision and scale, e.g.:
+// VARCHAR2(100 CHAR), NUMBER(10,2)
+func (c tabColumn) fullDataType() string {
+	switch c.DataType {
+	case "CHAR", "VARCHAR2":
+		if c.CharUsed.String == "C" {
+			return fmt.Sprintf("%s(%d CHAR)", c.DataType, c.CharLength)
 		}
+		return fmt.Sprintf("%s(%d)", c.DataType, c.DataLength)
+	case "NCHAR", "NVARCHAR2":
+		return fmt.Sprintf("%s(%d)", c.DataType, c.CharLength)
+	case "RAW":
+		return fmt.Sprintf("%s(%d)", c.DataType, c.DataLength)
+	case "NUMBER":
+		switch {
+		case !c.DataPrecision.Valid && c.DataScale.Valid:
+			// NUMBER(*,0), e.g.: INTEGER
+			return fmt.Sprintf("NUMBER(*,%d)", c.DataScale.Int64)
+		case !c.DataPrecision.Valid:
+			return c.DataType
+		}
+		return fmt.Sprintf("NUMBER(%d,%d)", c.DataPrecision.Int64, c.DataScale.Int64)
+	case "FLOAT":
+		if c.DataPrecision.Valid {
+			return fmt.Sprintf("FLOAT(%d)", c.DataPrecision.Int64)
+		}
+	}
+	return c.DataType
+}
+
+// normalizeDefault normalizes DATA_DEFAULT, which is the text of the default expression kept
+// as is, e.g.: 'abc' with the trailing spaces and newlines. The string literals are unquoted,
+// and NULL is taken as no default value.
+func normalizeDefault(dataDefault sql.NullString) sql.NullString {
+	value := strings.TrimSpace(dataDefault.String)
+	switch {
+	case !dataDefault.Valid, value == "", strings.EqualFold(value, "NULL"):
+		return sql.NullString{}
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return sql.NullString{String: value, Valid: true}
+}
+
+// CurrentSchema returns the owner and the name of the table, which are the names
+// stored in the data dictionary views, e.g.: `"MixedCase"` is returned as `MixedCase`,
+// and `customers` is returned as `CUSTOMERS`.
+func (m Migrator) CurrentSchema(stmt *gorm.Statement, table string) (string, string) {
+	if parts := splitIdentifier(table); len(parts) == 2 {
+		return dictionaryName(parts[0]), dictionaryName(parts[1])
 	}
 
-	return m.CurrentDatabase(), table
+	return m.CurrentDatabase(), dictionaryName(table)
 }
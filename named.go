@@ -0,0 +1,275 @@
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// callbackNamedBinds rewrites the named parameters of the SQL built by Raw and Exec.
+//
+// go-ora binds the parameters by position and ignores their names, so the named
+// parameters passed by sql.Named or map[string]interface{} are rewritten to the
+// positional ones in the order of their occurrences:
+//
+//	db.Raw("SELECT * FROM T WHERE ID = :id AND (NAME = :name OR ALIAS = :name)",
+//		sql.Named("id", 1), map[string]interface{}{"name": "x"})
+//	// SELECT * FROM T WHERE ID = :p1 AND (NAME = :p2 OR ALIAS = :p3)	[1, "x", "x"]
+//
+// The named parameters in the expressions of the clauses, e.g.: Where("ID = :id", sql.Named("id", 1)),
+// are rewritten by the clause builders while the statement is built, see: bindNamedClause.
+//
+// The named parameters of gorm in the form of `@name` are handled by gorm itself.
+func callbackNamedBinds(db *gorm.DB) {
+	if db.Error != nil || db.Statement.SQL.Len() == 0 || !hasNamedVars(db.Statement.Vars) {
+		return
+	}
+
+	query, vars, err := bindNamedVars(db.Statement.SQL.String(), db.Statement.Vars, false)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	db.Statement.SQL.Reset()
+	db.Statement.SQL.WriteString(query)
+	db.Statement.Vars = vars
+}
+
+// bindNamedClause wraps the clause builder to rewrite the named parameters of the expressions
+// to `?` placeholders before the clause is built, for the statements of all processors.
+func bindNamedClause(build clause.ClauseBuilder) clause.ClauseBuilder {
+	return func(c clause.Clause, builder clause.Builder) {
+		expression, err := bindNamedExpression(c.Expression)
+		if err != nil {
+			if stmt, ok := builder.(*gorm.Statement); ok {
+				stmt.AddError(err)
+			}
+			return
+		}
+		c.Expression = expression
+
+		if build != nil {
+			build(c, builder)
+		} else {
+			c.Build(builder)
+		}
+	}
+}
+
+// bindNamedExpression rewrites the named parameters of the expressions in the clause,
+// the expressions are copied, since they may be shared by the other statements.
+func bindNamedExpression(expression clause.Expression) (clause.Expression, error) {
+	var err error
+	switch e := expression.(type) {
+	case clause.Expr:
+		if hasNamedVars(e.Vars) {
+			e.SQL, e.Vars, err = bindNamedVars(e.SQL, e.Vars, true)
+		}
+		return e, err
+	case clause.Where:
+		e.Exprs, err = bindNamedExpressions(e.Exprs)
+		return e, err
+	case clause.AndConditions:
+		e.Exprs, err = bindNamedExpressions(e.Exprs)
+		return e, err
+	case clause.OrConditions:
+		e.Exprs, err = bindNamedExpressions(e.Exprs)
+		return e, err
+	case clause.NotConditions:
+		e.Exprs, err = bindNamedExpressions(e.Exprs)
+		return e, err
+	case clause.GroupBy:
+		e.Having, err = bindNamedExpressions(e.Having)
+		return e, err
+	case clause.Select:
+		if e.Expression != nil {
+			e.Expression, err = bindNamedExpression(e.Expression)
+		}
+		return e, err
+	case clause.OnConflict:
+		if len(e.DoUpdates) > 0 {
+			var set clause.Expression
+			if set, err = bindNamedExpression(e.DoUpdates); err != nil {
+				return e, err
+			}
+			e.DoUpdates = set.(clause.Set)
+		}
+		e.Where.Exprs, err = bindNamedExpressions(e.Where.Exprs)
+		return e, err
+	case clause.Set:
+		set := make(clause.Set, len(e))
+		for i, assignment := range e {
+			if value, ok := assignment.Value.(clause.Expression); ok {
+				if assignment.Value, err = bindNamedExpression(value); err != nil {
+					return e, err
+				}
+			}
+			set[i] = assignment
+		}
+		return set, nil
+	case clause.Values:
+		values := make([][]interface{}, len(e.Values))
+		for i, row := range e.Values {
+			values[i] = make([]interface{}, len(row))
+			for k, value := range row {
+				if v, ok := value.(clause.Expression); ok {
+					if value, err = bindNamedExpression(v); err != nil {
+						return e, err
+					}
+				}
+				values[i][k] = value
+			}
+		}
+		e.Values = values
+		return e, nil
+	}
+	return expression, nil
+}
+
+func bindNamedExpressions(expressions []clause.Expression) ([]clause.Expression, error) {
+	if expressions == nil {
+		return nil, nil
+	}
+
+	result := make([]clause.Expression, len(expressions))
+	for i, e := range expressions {
+		var err error
+		if result[i], err = bindNamedExpression(e); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// hasNamedVars returns true if any of the vars is a named parameter
+func hasNamedVars(vars []interface{}) bool {
+	for _, v := range vars {
+		switch v := v.(type) {
+		case sql.NamedArg:
+			if v.Name != "" {
+				return true
+			}
+		case map[string]interface{}:
+			return true
+		}
+	}
+	return false
+}
+
+// bindNamedVars replaces the named parameters `:name` in query with the positional
+// parameters `:pN`, or `?` of gorm if expr is true, and returns the vars in the order
+// of the positional parameters.
+//
+// The names are case-insensitive, and the same name can be used more than once.
+// The parameters which are not found in the named vars, e.g. `:p1` written by
+// BindVarTo or `:1`, and `?` if expr is true, take the unnamed vars in order.
+// String literals, quoted identifiers and comments are left as they are.
+func bindNamedVars(query string, vars []interface{}, expr bool) (string, []interface{}, error) {
+	var (
+		named      = make(map[string]interface{}, len(vars))
+		positional = make([]interface{}, 0, len(vars))
+	)
+	for _, v := range vars {
+		switch v := v.(type) {
+		case sql.NamedArg:
+			if v.Name == "" {
+				positional = append(positional, v.Value)
+			} else {
+				named[strings.ToUpper(v.Name)] = v.Value
+			}
+		case map[string]interface{}:
+			for name, value := range v {
+				named[strings.ToUpper(name)] = value
+			}
+		default:
+			positional = append(positional, v)
+		}
+	}
+
+	var (
+		builder strings.Builder
+		result  = make([]interface{}, 0, len(vars))
+		idx     int
+	)
+	builder.Grow(len(query))
+
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '\'' || c == '"':
+			// string literal or quoted identifier
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				end = len(query) - i - 2
+			}
+			builder.WriteString(query[i : i+end+2])
+			i += end + 1
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			builder.WriteString(query[i : i+end])
+			i += end - 1
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i - 4
+			}
+			builder.WriteString(query[i : i+end+4])
+			i += end + 3
+		case c == ':' && i+1 < len(query) && (isBindNameStart(query[i+1]) || isDigit(query[i+1])):
+			j := i + 1
+			for j < len(query) && isBindNamePart(query[j]) {
+				j++
+			}
+
+			name := query[i+1 : j]
+			if value, ok := named[strings.ToUpper(name)]; ok {
+				result = append(result, value)
+			} else if idx < len(positional) {
+				result = append(result, positional[idx])
+				idx++
+			} else {
+				return "", nil, fmt.Errorf("missing value of the named parameter :%s", name)
+			}
+
+			if expr {
+				builder.WriteByte('?')
+			} else {
+				builder.WriteString(fmt.Sprintf(":p%d", len(result)))
+			}
+			i = j - 1
+		case c == '?' && expr:
+			if idx >= len(positional) {
+				return "", nil, fmt.Errorf("missing value of the parameter ?")
+			}
+			result = append(result, positional[idx])
+			idx++
+			builder.WriteByte(c)
+		default:
+			builder.WriteByte(c)
+		}
+	}
+
+	if idx < len(positional) {
+		return "", nil, fmt.Errorf("%d unnamed parameters are not used", len(positional)-idx)
+	}
+
+	return builder.String(), result, nil
+}
+
+func isBindNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isBindNamePart(c byte) bool {
+	return isBindNameStart(c) || isDigit(c) || c == '$' || c == '#'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,94 @@
+package oracle
+
+import (
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+// NamingStrategy generates the uppercase names which match the data dictionary of
+// Oracle, so that the `column` tags and `TableName()` methods are not required:
+//
+//	type CustomerOrder struct {
+//		OrderID   int64
+//		CreatedAt time.Time
+//	}
+//	// table: CUSTOMER_ORDERS, columns: ORDER_ID, CREATED_AT
+//
+// It is used by default, unless the NamingStrategy of gorm.Config is specified
+// or Config.DontUseNamingStrategy is true.
+type NamingStrategy struct {
+	schema.NamingStrategy
+
+	// Owner is the schema owner prefixed to the table names, e.g.: APP.CUSTOMER_ORDERS
+	Owner string
+}
+
+var _ schema.Namer = NamingStrategy{}
+
+// TableName implements schema.Namer interface
+func (ns NamingStrategy) TableName(str string) string {
+	return ns.withOwner(strings.ToUpper(ns.NamingStrategy.TableName(str)))
+}
+
+// SchemaName implements schema.Namer interface
+func (ns NamingStrategy) SchemaName(table string) string {
+	table = ns.trimOwner(table)
+	if prefix := ns.TablePrefix; prefix != "" && len(table) >= len(prefix) && strings.EqualFold(table[:len(prefix)], prefix) {
+		table = table[len(prefix):]
+	}
+
+	// the schema name is converted from the lowercase table name without prefix
+	namer := ns.NamingStrategy
+	namer.TablePrefix = ""
+	return namer.SchemaName(strings.ToLower(table))
+}
+
+// ColumnName implements schema.Namer interface
+func (ns NamingStrategy) ColumnName(table, column string) string {
+	return strings.ToUpper(ns.NamingStrategy.ColumnName(table, column))
+}
+
+// JoinTableName implements schema.Namer interface
+func (ns NamingStrategy) JoinTableName(str string) string {
+	return ns.withOwner(strings.ToUpper(ns.NamingStrategy.JoinTableName(str)))
+}
+
+// RelationshipFKName implements schema.Namer interface
+func (ns NamingStrategy) RelationshipFKName(rel schema.Relationship) string {
+	return ns.formatName("FK", rel.Schema.Table, ns.NamingStrategy.ColumnName("", rel.Name))
+}
+
+// CheckerName implements schema.Namer interface
+func (ns NamingStrategy) CheckerName(table, column string) string {
+	return ns.formatName("CHK", table, column)
+}
+
+// IndexName implements schema.Namer interface
+func (ns NamingStrategy) IndexName(table, column string) string {
+	return ns.formatName("IDX", table, ns.NamingStrategy.ColumnName("", column))
+}
+
+// formatName joins the parts by `_` without the owner of table, e.g.: IDX_CUSTOMER_ORDERS_CREATED_AT
+func (ns NamingStrategy) formatName(prefix, table, name string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.Join([]string{
+		prefix, ns.trimOwner(table), name,
+	}, "_"), ".", "_"))
+}
+
+func (ns NamingStrategy) withOwner(table string) string {
+	if ns.Owner == "" {
+		return table
+	}
+	return dictionaryCase(ns.Owner) + "." + table
+}
+
+func (ns NamingStrategy) trimOwner(table string) string {
+	if ns.Owner == "" {
+		return table
+	}
+	if prefix := ns.Owner + "."; len(table) > len(prefix) && strings.EqualFold(table[:len(prefix)], prefix) {
+		return table[len(prefix):]
+	}
+	return table
+}
@@ -1,6 +1,9 @@
 package oracle
 
 import (
+	"database/sql"
+	"time"
+
 	_ "github.com/sijms/go-ora/v2"
 
 	"gorm.io/gorm"
@@ -18,15 +21,73 @@ type Config struct {
 	DontSupportRenameColumn       bool
 	DontSupportNullAsDefaultValue bool
 
-	// DontSupportIdentity 为 true 时表明不支持 IDENTITY 关键字
-	// See: https://docs.oracle.com/database/121/DRDAA/migr_tools_feat.htm#DRDAA109
-	supportIdentity bool
+	// DontUseNamingStrategy 为 true 时不使用 oracle.NamingStrategy 生成大写的表名、列名等，
+	// 使用 gorm 默认的小写命名。gorm.Config 中指定了 NamingStrategy 时以其为准。
+	DontUseNamingStrategy bool
+
+	// QuoteMode 决定标识符（表名、列名等）的引用方式，默认为 QuoteNever：
+	// - QuoteNever: 原样输出，由 Oracle 转为大写
+	// - QuoteReservedWords: 仅对保留字（如 LEVEL、DATE、COMMENT）及非法标识符转为大写并加双引号
+	// - QuoteAlways: 全部转为大写并加双引号
+	// 已经加了双引号的部分原样输出，可用于访问大小写敏感的对象。
+	QuoteMode QuoteMode
+
+	// BatchInsertWithArrayBinding 为 true 时批量插入使用数组绑定（Array DML）执行：
+	// 每一列的值作为一个数组绑定到同一条 INSERT INTO t (cols) VALUES (:1,:2,...) 语句。
+	// 仅支持默认的 DriverName，且不支持 PrepareStmt 模式。
+	BatchInsertWithArrayBinding bool
+
+	// MaxOpenConns、MaxIdleConns、ConnMaxLifetime、ConnMaxIdleTime 为连接池配置，
+	// 非零值时应用到 sql.DB，见 sql.DB.SetMaxOpenConns 等。
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// 以下会话参数非空时，连接池中的每个新连接都会执行 ALTER SESSION SET ... 进行设置，
+	// 避免日期、数字的格式受服务器默认 NLS 设置的影响。Initialize 会读取会话参数验证是否生效。
+	// NLSDateFormat 如 YYYY-MM-DD HH24:MI:SS
+	NLSDateFormat string
+	// NLSTimestampFormat 如 YYYY-MM-DD HH24:MI:SS.FF6
+	NLSTimestampFormat string
+	// NLSTimestampTZFormat 如 YYYY-MM-DD HH24:MI:SS.FF6 TZH:TZM
+	NLSTimestampTZFormat string
+	// NLSNumericCharacters 为小数点和千分位分隔符，如 .,
+	NLSNumericCharacters string
+	// NLSSort 如 BINARY、BINARY_CI
+	NLSSort string
+	// NLSComp 如 BINARY、LINGUISTIC
+	NLSComp string
+	// TimeZone 如 +08:00、Asia/Shanghai、LOCAL、DBTIMEZONE
+	TimeZone string
+	// CurrentSchema 为默认的 schema，区分大小写时需加双引号
+	CurrentSchema string
+	// SessionStatements 为每个新连接在上述设置之后额外执行的语句，如 ALTER SESSION SET DDL_LOCK_TIMEOUT = 10
+	SessionStatements []string
+	// SkipSessionVerification 为 true 时 gorm.Open 不读回会话参数验证上述设置是否生效，
+	// 与 SkipInitializeWithVersion 无关，DryRun 时不验证
+	SkipSessionVerification bool
+
+	// AppContextPackage 为 oracle.WithAppContext 设置应用上下文（VPD）时调用的包，默认为 DBMS_SESSION。
+	// 由 CREATE CONTEXT ... USING 指定的包才能设置该上下文，该包需提供与 DBMS_SESSION 参数相同的
+	// SET_CONTEXT(namespace, attribute, value) 和 CLEAR_CONTEXT(namespace, client_id, attribute)。
+	AppContextPackage string
+
+	// envErr 为解析环境变量时的错误，由 Initialize 返回
+	envErr error
 
-	// supportOffsetFetch 为 true 时支持 OFFSET ... FETCH ... 子句
-	// See:
-	// - https://docs.oracle.com/database/121/SQLRF/statements_10002.htm#SQLRF55636
-	// - https://support.oracle.com/knowledge/Oracle%20Database%20Products/1600130_1.html#GOAL
-	supportOffsetFetch bool
+	// Capabilities 不为 nil 时强制使用指定的特性，而不是由服务器版本决定，多用于测试
+	Capabilities *Capabilities
+
+	// version 为由 ServerVersion 解析得到的版本号
+	version Version
+	// compatible 为 COMPATIBLE 参数的值，如 12.1.0，无权限读取时为空
+	compatible string
+	// capabilities 为服务器支持的特性
+	capabilities Capabilities
+
+	// wrapped 为 true 时连接池由 connector 创建，语句由包装后的 go-ora 连接执行，见 wrapsConnPool
+	wrapped bool
 }
 
 func Open(dsn string) gorm.Dialector {
@@ -37,7 +98,38 @@ func New(config Config) gorm.Dialector {
 	return &Dialector{Config: (&config).applyEnv()}
 }
 
-func (c *Config) applyEnv() *Config {
-	// TODO: apply exists env
-	return c
+// applyConnPool applies the configuration of connection pool
+func (c *Config) applyConnPool(db *sql.DB) {
+	if c.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(c.MaxOpenConns)
+	}
+	if c.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(c.MaxIdleConns)
+	}
+	if c.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	}
+	if c.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+	}
+}
+
+// applyServerVersion decides the capabilities by ServerVersion and the COMPATIBLE
+// parameter, or by Config.Capabilities if it is specified.
+func (c *Config) applyServerVersion() {
+	// the unrecognized version is taken as the oldest one, e.g.: 11g
+	c.version, _ = ParseVersion(c.ServerVersion)
+
+	// the features are also limited by the COMPATIBLE parameter, e.g.: the long identifiers
+	// are not supported by 19c with COMPATIBLE=12.1.0
+	capabilitiesVersion := c.version
+	if compatible, err := ParseVersion(c.compatible); err == nil && compatible.Less(capabilitiesVersion) {
+		capabilitiesVersion = compatible
+	}
+
+	if c.Capabilities != nil {
+		c.capabilities = *c.Capabilities
+	} else {
+		c.capabilities = CapabilitiesOf(capabilitiesVersion)
+	}
 }
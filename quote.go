@@ -0,0 +1,155 @@
+package oracle
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// QuoteMode decides how the identifiers are quoted by Dialector.QuoteTo
+type QuoteMode int
+
+const (
+	// QuoteNever writes the identifiers as they are, Oracle folds them to uppercase.
+	QuoteNever QuoteMode = iota
+	// QuoteReservedWords quotes the reserved words and the identifiers which are
+	// invalid without quotes, e.g.: LEVEL, DATE, COMMENT, SIZE, USER. They are
+	// folded to uppercase before quoting, to match the unquoted ones.
+	QuoteReservedWords
+	// QuoteAlways quotes all the identifiers after folding them to uppercase.
+	QuoteAlways
+)
+
+// reservedWords are the reserved words of Oracle, which can not be used as
+// nonquoted identifiers.
+// See: https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/Oracle-SQL-Reserved-Words.html
+var reservedWords = map[string]struct{}{}
+
+func init() {
+	for _, word := range strings.Fields(`
+		ACCESS ADD ALL ALTER AND ANY AS ASC AUDIT BETWEEN BY CHAR CHECK CLUSTER
+		COLUMN COLUMN_VALUE COMMENT COMPRESS CONNECT CREATE CURRENT DATE DECIMAL
+		DEFAULT DELETE DESC DISTINCT DROP ELSE EXCLUSIVE EXISTS FILE FLOAT FOR
+		FROM GRANT GROUP HAVING IDENTIFIED IMMEDIATE IN INCREMENT INDEX INITIAL
+		INSERT INTEGER INTERSECT INTO IS LEVEL LIKE LOCK LONG MAXEXTENTS MINUS
+		MLSLABEL MODE MODIFY NESTED_TABLE_ID NOAUDIT NOCOMPRESS NOT NOWAIT NULL
+		NUMBER OF OFFLINE ON ONLINE OPTION OR ORDER PCTFREE PRIOR PUBLIC RAW
+		RENAME RESOURCE REVOKE ROW ROWID ROWNUM ROWS SELECT SESSION SET SHARE
+		SIZE SMALLINT START SUCCESSFUL SYNONYM SYSDATE TABLE THEN TO TRIGGER UID
+		UNION UNIQUE UPDATE USER VALIDATE VALUES VARCHAR VARCHAR2 VIEW WHENEVER
+		WHERE WITH`) {
+		reservedWords[word] = struct{}{}
+	}
+}
+
+// IsReservedWord returns true if the name is a reserved word of Oracle
+func IsReservedWord(name string) bool {
+	_, ok := reservedWords[strings.ToUpper(name)]
+	return ok
+}
+
+// QuoteTo implements gorm.Dialector interface
+//
+// The dotted names, e.g.: `schema.table` and `table.column`, are quoted part by part.
+// The parts already quoted are written as they are, so that the case-sensitive
+// objects can be used in any mode, e.g.: `"MixedCase".COLUMN`.
+//
+// The parts longer than IdentifierMaxLength are shortened by ShortenIdentifier,
+// e.g.: the names of indexes and constraints generated by gorm.
+func (dialector Dialector) QuoteTo(writer clause.Writer, str string) {
+	mode := QuoteNever
+	if dialector.Config != nil {
+		mode = dialector.Config.QuoteMode
+	}
+
+	maxLength := dialector.IdentifierMaxLength()
+	for idx, part := range splitIdentifier(str) {
+		if idx > 0 {
+			writer.WriteByte('.')
+		}
+
+		part = ShortenIdentifier(part, maxLength)
+
+		if isQuoted(part) || part == "*" || part == "" {
+			writer.WriteString(part)
+			continue
+		}
+
+		switch {
+		case mode == QuoteAlways,
+			mode == QuoteReservedWords && (IsReservedWord(part) || !isNonquotedIdentifier(part)):
+			writer.WriteByte('"')
+			writer.WriteString(strings.ToUpper(part))
+			writer.WriteByte('"')
+		default:
+			writer.WriteString(part)
+		}
+	}
+}
+
+// splitIdentifier splits the dotted name, the dots inside quotes are kept.
+func splitIdentifier(str string) []string {
+	var (
+		parts    = make([]string, 0, 2)
+		inQuotes bool
+		start    int
+	)
+	for i := 0; i < len(str); i++ {
+		switch str[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case '.':
+			if !inQuotes {
+				parts = append(parts, str[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, str[start:])
+}
+
+// dictionaryName returns the name stored in the data dictionary views, e.g.: ALL_TABLES.
+// Nonquoted identifiers are stored in uppercase, and the quoted ones as they are.
+func dictionaryName(str string) string {
+	if isQuoted(str) {
+		return str[1 : len(str)-1]
+	}
+	return strings.ToUpper(str)
+}
+
+// dictionaryCase folds the nonquoted identifier to uppercase, the quoted one is kept as it is.
+func dictionaryCase(str string) string {
+	if isQuoted(str) {
+		return str
+	}
+	return strings.ToUpper(str)
+}
+
+func isQuoted(str string) bool {
+	return len(str) >= 2 && str[0] == '"' && str[len(str)-1] == '"'
+}
+
+// isIdentifier returns true if str is a quoted identifier or a valid nonquoted identifier
+func isIdentifier(str string) bool {
+	if isQuoted(str) {
+		return !strings.Contains(str[1:len(str)-1], `"`)
+	}
+	return str != "" && isNonquotedIdentifier(str)
+}
+
+// isNonquotedIdentifier returns true if str can be used without quotes: begins
+// with a letter, and contains only letters, digits, `_`, `$` and `#`.
+// See: https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/Database-Object-Names-and-Qualifiers.html
+func isNonquotedIdentifier(str string) bool {
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			continue
+		}
+		if i > 0 && (isDigit(c) || c == '_' || c == '$' || c == '#') {
+			continue
+		}
+		return false
+	}
+	return true
+}
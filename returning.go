@@ -0,0 +1,323 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+
+	go_ora "github.com/sijms/go-ora/v2"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrReturningOverflow is returned by UPDATE/DELETE RETURNING when the rows affected still exceed the
+// out parameters after the statement is executed again with them enlarged, e.g.: the rows matched by the
+// conditions keep increasing by the concurrent sessions. The statement is rolled back by the PL/SQL block.
+var ErrReturningOverflow = errors.New("more rows affected than the returning out parameters")
+
+// returningSavePoint is the savepoint rolled back to by the PL/SQL block of UPDATE/DELETE RETURNING,
+// if the returned rows exceed the out parameters.
+const returningSavePoint = "GORM_ORACLE_RETURNING"
+
+// returningAttempts is the max times to execute UPDATE/DELETE RETURNING, see: ErrReturningOverflow
+const returningAttempts = 3
+
+// returningHolder holds the values returned by UPDATE/DELETE statements, which are returned
+// by the out array parameters, one array for each field.
+type returningHolder struct {
+	fields       []*schema.Field
+	rowsAffected *int64
+	// capacity is the max rows can be returned by the out parameters
+	capacity int
+	// index is the position of the capacity in the vars of the statement, followed by the out parameters
+	index int
+	// dest is the destination slice, which is reset by gorm.Scan on the empty result set
+	dest reflect.Value
+}
+
+// isUpdateOrDeleteReturning returns true if the UPDATE/DELETE statement has RETURNING clause
+func isUpdateOrDeleteReturning(stmt *gorm.Statement) bool {
+	if isReturning, _ := hasReturning(stmt); !isReturning || stmt.Schema == nil {
+		return false
+	}
+
+	for _, name := range stmt.BuildClauses {
+		if name == ClauseUpdate || name == ClauseDelete {
+			return true
+		}
+	}
+	return false
+}
+
+// returningCapacity returns the rows expected to be returned, which is the count of destination
+// elements, at least 1, since the out array parameter of no element is bound as a scalar.
+func returningCapacity(stmt *gorm.Statement) int {
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		if stmt.ReflectValue.Len() > 0 {
+			return stmt.ReflectValue.Len()
+		}
+	}
+	return 1
+}
+
+// returningOut returns the out array parameter of the field for at most capacity rows, whose
+// elements are the nullable types supported by go-ora, the others are returned as strings.
+func returningOut(field *schema.Field, capacity int) go_ora.Out {
+	var dest interface{}
+	switch field.DataType {
+	case schema.Bool:
+		dest = &[]sql.NullBool{}
+	case schema.Int, schema.Uint:
+		dest = &[]sql.NullInt64{}
+	case schema.Float:
+		dest = &[]sql.NullFloat64{}
+	case schema.Time:
+		dest = &[]go_ora.NullTimeStamp{}
+	default:
+		dest = &[]sql.NullString{}
+	}
+	return go_ora.Out{Dest: dest, Size: capacity}
+}
+
+// resize replaces the capacity and the out parameters in the vars of the statement
+func (holder *returningHolder) resize(stmt *gorm.Statement, capacity int) {
+	holder.capacity = capacity
+	stmt.Vars[holder.index] = capacity
+	for k, f := range holder.fields {
+		stmt.Vars[holder.index+1+k] = returningOut(f, capacity)
+	}
+}
+
+// values returns the values of the rows returned by the out parameters
+func (holder *returningHolder) values(stmt *gorm.Statement) ([][]interface{}, error) {
+	var rows [][]interface{}
+	for k := range holder.fields {
+		out, _ := stmt.Vars[holder.index+1+k].(go_ora.Out)
+		column := reflect.ValueOf(out.Dest).Elem()
+		for r := 0; r < column.Len(); r++ {
+			if r == len(rows) {
+				rows = append(rows, make([]interface{}, len(holder.fields)))
+			}
+			value, err := column.Index(r).Addr().Interface().(driver.Valuer).Value()
+			if err != nil {
+				return nil, err
+			}
+			rows[r][k] = value
+		}
+	}
+	return rows, nil
+}
+
+// buildReturningDeclare writes the DECLARE part of the PL/SQL block for UPDATE/DELETE RETURNING:
+//
+//	DECLARE
+//		TYPE t_o0 IS TABLE OF Customers.CUSTOMER_ID%TYPE;
+//		o0 t_o0;
+//	BEGIN
+//		SAVEPOINT GORM_ORACLE_RETURNING;
+func (d Dialector) buildReturningDeclare(stmt *gorm.Statement, builder clause.Builder) {
+	fields := returningFields(stmt)
+	// primary keys are always returned to match the elements of destination
+	for _, pf := range stmt.Schema.PrimaryFields {
+		if !containsField(fields, pf) {
+			fields = append(fields, pf)
+		}
+	}
+
+	holder := &returningHolder{fields: fields, rowsAffected: new(int64)}
+	stmt.Context = context.WithValue(stmt.Context, ctxKeyReturning, holder)
+
+	builder.WriteString("DECLARE\n")
+	for k, f := range fields {
+		builder.WriteString(fmt.Sprintf("\tTYPE t_o%d IS TABLE OF %s%%TYPE;\n", k, stmt.Quote(clause.Column{Table: stmt.Table, Name: f.DBName})))
+		builder.WriteString(fmt.Sprintf("\to%d t_o%d;\n", k, k))
+	}
+	builder.WriteString("BEGIN\n\tSAVEPOINT " + returningSavePoint + ";\n\t")
+}
+
+// buildReturningCollect writes the rest part of the PL/SQL block for UPDATE/DELETE RETURNING, the
+// rows are returned by the out array parameters, which are bound for the elements of the destination.
+// The statement is rolled back if the rows exceed the out parameters, see: callbackReturning.
+//
+//	RETURNING CUSTOMER_ID BULK COLLECT INTO o0;
+//		:rows_affected := SQL%ROWCOUNT;
+//		IF o0.COUNT > :capacity THEN
+//			ROLLBACK TO GORM_ORACLE_RETURNING;
+//		ELSE
+//			FOR i IN 1 .. o0.COUNT LOOP
+//				:o0(i) := o0(i);
+//			END LOOP;
+//		END IF;
+//	END;
+func (d Dialector) buildReturningCollect(stmt *gorm.Statement, builder clause.Builder) {
+	holder, ok := stmt.Context.Value(ctxKeyReturning).(*returningHolder)
+	if !ok {
+		return
+	}
+
+	builder.WriteString("RETURNING ")
+	for k, f := range holder.fields {
+		if k > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteQuoted(f.DBName)
+	}
+	builder.WriteString(" BULK COLLECT INTO ")
+	for k := range holder.fields {
+		if k > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(fmt.Sprintf("o%d", k))
+	}
+	builder.WriteString(";\n")
+
+	stmt.Vars = append(stmt.Vars, holder.rowsAffected)
+	builder.WriteString("\t:rows_affected := SQL%ROWCOUNT;\n")
+	stmt.Context = context.WithValue(stmt.Context, ctxKeyRowsAffected, holder.rowsAffected)
+
+	if stmt.ReflectValue.Kind() == reflect.Slice {
+		holder.dest = reflect.ValueOf(stmt.ReflectValue.Interface())
+	}
+
+	holder.index = len(stmt.Vars)
+	stmt.Vars = append(stmt.Vars, make([]interface{}, 1+len(holder.fields))...)
+	holder.resize(stmt, returningCapacity(stmt))
+
+	builder.WriteString("\tIF o0.COUNT > :capacity THEN\n")
+	builder.WriteString("\t\tROLLBACK TO " + returningSavePoint + ";\n")
+	builder.WriteString("\tELSE\n")
+	builder.WriteString("\t\tFOR i IN 1 .. o0.COUNT LOOP\n")
+	for k := range holder.fields {
+		builder.WriteString(fmt.Sprintf("\t\t\t:o%d(i) := o%d(i);\n", k, k))
+	}
+	builder.WriteString("\t\tEND LOOP;\n")
+	builder.WriteString("\tEND IF;\n")
+	builder.WriteString("END;")
+}
+
+// callbackReturning writes the values returned by UPDATE/DELETE into the destination. The statement
+// is executed again with the out parameters enlarged if it is rolled back by the PL/SQL block, since
+// the rows exceed them. The returned rows are matched to the elements by primary keys, the others fill
+// the elements not matched in order, and then are appended to the destination slice.
+func callbackReturning(db *gorm.DB) {
+	if db.Error != nil || db.DryRun {
+		return
+	}
+
+	stmt := db.Statement
+	holder, ok := stmt.Context.Value(ctxKeyReturning).(*returningHolder)
+	if !ok {
+		return
+	}
+
+	if holder.dest.IsValid() && stmt.ReflectValue.CanSet() {
+		stmt.ReflectValue.Set(holder.dest)
+	}
+
+	for attempts := 1; int(*holder.rowsAffected) > holder.capacity; attempts++ {
+		if attempts == returningAttempts {
+			db.AddError(fmt.Errorf("%w: %d rows affected, %d out parameters", ErrReturningOverflow, *holder.rowsAffected, holder.capacity))
+			return
+		}
+
+		holder.resize(stmt, int(*holder.rowsAffected))
+		rows, err := stmt.ConnPool.QueryContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+		if err != nil {
+			db.AddError(translateError(err))
+			return
+		}
+		db.AddError(rows.Close())
+	}
+
+	values, err := holder.values(stmt)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	var elements []reflect.Value
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Struct:
+		elements = []reflect.Value{stmt.ReflectValue}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			elements = append(elements, reflect.Indirect(stmt.ReflectValue.Index(i)))
+		}
+	}
+
+	// the elements are matched by primary keys, or by order if there are no primary keys
+	hasPrimaryKey := len(stmt.Schema.PrimaryFields) > 0
+	primaryKeyOf := func(rv reflect.Value) (key string, zero bool) {
+		zero = true
+		for _, pf := range stmt.Schema.PrimaryFields {
+			value, isZero := pf.ValueOf(stmt.Context, rv)
+			key += fmt.Sprintf("%v;", value)
+			zero = zero && isZero
+		}
+		return
+	}
+
+	var (
+		elementIndexes = make(map[string]int, len(elements))
+		// vacancies are the elements without primary keys, which are filled by the unmatched rows in order
+		vacancies []int
+	)
+	for i, rv := range elements {
+		if key, zero := primaryKeyOf(rv); hasPrimaryKey && !zero {
+			elementIndexes[key] = i
+		} else {
+			vacancies = append(vacancies, i)
+		}
+	}
+
+	// the returned values are set to a new element first, so that the primary keys are
+	// compared with the same types as the elements
+	appended := make([]reflect.Value, 0)
+	for _, row := range values {
+		rv := reflect.New(stmt.Schema.ModelType).Elem()
+		for k, f := range holder.fields {
+			db.AddError(f.Set(stmt.Context, rv, row[k]))
+		}
+
+		key, _ := primaryKeyOf(rv)
+		i, ok := elementIndexes[key]
+		switch {
+		case ok && hasPrimaryKey:
+			delete(elementIndexes, key)
+		case len(vacancies) > 0:
+			i, vacancies = vacancies[0], vacancies[1:]
+		default:
+			appended = append(appended, rv)
+			continue
+		}
+		for k, f := range holder.fields {
+			db.AddError(f.Set(stmt.Context, elements[i], row[k]))
+		}
+	}
+
+	if len(appended) > 0 && stmt.ReflectValue.Kind() == reflect.Slice && stmt.ReflectValue.CanSet() {
+		dest := stmt.ReflectValue
+		for _, rv := range appended {
+			if dest.Type().Elem().Kind() == reflect.Ptr {
+				rv = rv.Addr()
+			}
+			dest = reflect.Append(dest, rv)
+		}
+		stmt.ReflectValue.Set(dest)
+	}
+}
+
+func containsField(fields []*schema.Field, field *schema.Field) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
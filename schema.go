@@ -0,0 +1,115 @@
+package oracle
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+const ctxKeySchema string = "schema"
+
+// ErrSchemaNotSwitched is returned by the statements of WithSchema, if CURRENT_SCHEMA can not be
+// switched by the connection pool, rather than running the statements against the default schema.
+var ErrSchemaNotSwitched = errors.New("schema can not be switched")
+
+// WithSchema returns a scope to run the statements against the schema, e.g.: the schema of a tenant:
+//
+//	db.Scopes(oracle.WithSchema("TENANT_42")).Find(&users)
+//	db.Scopes(oracle.WithSchema("TENANT_42")).Migrator().HasTable(&User{})
+//
+// CURRENT_SCHEMA of the connection is switched before the statements, and restored when the
+// connection is reused from the pool, so that the schema never leaks to other requests.
+// The case-sensitive schema should be quoted, e.g.: `"Tenant42"`.
+//
+// The schema is switched by the wrapped connections of the default DriverName only, ErrSchemaNotSwitched
+// is added if the statements are executed by other pools, e.g.: Config.Conn or gorm.Config.PrepareStmt.
+func WithSchema(schema string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		name, err := schemaIdentifier(schema)
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		if dialector, ok := dialectorOf(db); ok && !dialector.wrapsConnPool(db.Statement.ConnPool) {
+			_ = db.AddError(fmt.Errorf("%w: %s by the connection pool %T", ErrSchemaNotSwitched, schema, db.Statement.ConnPool))
+			return db
+		}
+		db.Statement.Context = context.WithValue(db.Statement.Context, ctxKeySchema, name)
+		return db
+	}
+}
+
+// schemaOf returns the schema specified by WithSchema, which is an identifier in SQL
+func schemaOf(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	schema, ok := ctx.Value(ctxKeySchema).(string)
+	return schema, ok && schema != ""
+}
+
+// schemaIdentifier validates the schema and folds the nonquoted one to uppercase
+func schemaIdentifier(schema string) (string, error) {
+	if !isIdentifier(schema) {
+		return "", fmt.Errorf("invalid schema %q, quote it if it is case sensitive", schema)
+	}
+	return dictionaryCase(schema), nil
+}
+
+// switchSchema switches CURRENT_SCHEMA of the connection to the schema specified by WithSchema,
+// the original schema is kept to be restored by restoreSchema.
+func (c *conn) switchSchema(ctx context.Context) error {
+	schema, ok := schemaOf(ctx)
+	if !ok || schema == c.schema {
+		return nil
+	}
+
+	if c.defaultSchema == "" {
+		defaultSchema, err := c.queryString(ctx, "SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL")
+		if err != nil {
+			return c.check(err)
+		}
+		c.defaultSchema = `"` + defaultSchema + `"`
+	}
+
+	if _, err := c.Connection.ExecContext(ctx, "ALTER SESSION SET CURRENT_SCHEMA = "+schema, nil); err != nil {
+		return c.check(err)
+	}
+	c.schema = schema
+	return nil
+}
+
+// restoreSchema restores CURRENT_SCHEMA of the connection switched by switchSchema
+func (c *conn) restoreSchema(ctx context.Context) error {
+	if c.schema == "" {
+		return nil
+	}
+
+	if _, err := c.Connection.ExecContext(ctx, "ALTER SESSION SET CURRENT_SCHEMA = "+c.defaultSchema, nil); err != nil {
+		return c.check(err)
+	}
+	c.schema = ""
+	return nil
+}
+
+// queryString returns the first column of the first row of query
+func (c *conn) queryString(ctx context.Context, query string) (string, error) {
+	rows, err := c.Connection.QueryContext(ctx, query, nil)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	values := make([]driver.Value, len(rows.Columns()))
+	if err = rows.Next(values); err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("no column returned by %s", query)
+	}
+	s, _ := values[0].(string)
+	return s, nil
+}
@@ -0,0 +1,178 @@
+package oracle
+
+import (
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SequenceOptions are the options of CREATE SEQUENCE and ALTER SEQUENCE, the zero values are
+// omitted, so that the defaults of Oracle are used, e.g.: INCREMENT BY 1 CACHE 20 NOORDER.
+type SequenceOptions struct {
+	// StartWith is the first value generated by the sequence, START WITH
+	StartWith int64
+	// StartWithMaxOf is the field or column of the table, the sequence starts with its MAX value
+	// plus IncrementBy, e.g.: the sequence of the existing table, StartWith is ignored if specified.
+	StartWithMaxOf string
+	// IncrementBy is the interval between the values, INCREMENT BY
+	IncrementBy int64
+	// Cache is the number of values preallocated in memory, CACHE n, negative for NOCACHE
+	Cache int
+	// Order guarantees the values are generated in order of request, ORDER, e.g.: for RAC
+	Order bool
+}
+
+// clause returns the options as the clauses of CREATE SEQUENCE or ALTER SEQUENCE
+func (opts SequenceOptions) clause() (sql string) {
+	if opts.IncrementBy != 0 {
+		sql += " INCREMENT BY " + strconv.FormatInt(opts.IncrementBy, 10)
+	}
+	switch {
+	case opts.Cache > 0:
+		sql += " CACHE " + strconv.Itoa(opts.Cache)
+	case opts.Cache < 0:
+		sql += " NOCACHE"
+	}
+	if opts.Order {
+		sql += " ORDER"
+	}
+	return
+}
+
+// CreateSequence creates the sequence with opts, the sequence name may be qualified by the owner,
+// e.g.: APP.CUSTOMERS_S, and value is the model whose table is read by StartWithMaxOf:
+//
+//	db.Migrator().(oracle.Migrator).CreateSequence(&Customer{}, "CUSTOMERS_S", oracle.SequenceOptions{StartWithMaxOf: "CustomerID"})
+//	// CREATE SEQUENCE CUSTOMERS_S START WITH 101
+func (m Migrator) CreateSequence(value interface{}, name string, opts SequenceOptions) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		start, err := m.sequenceStart(stmt, opts)
+		if err != nil {
+			return err
+		}
+		return m.DB.Exec("CREATE SEQUENCE ?"+start+opts.clause(), clause.Table{Name: name}).Error
+	})
+}
+
+// DropSequence drops the sequence
+func (m Migrator) DropSequence(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Exec("DROP SEQUENCE ?", clause.Table{Name: name}).Error
+	})
+}
+
+// HasSequence checks the sequence by ALL_SEQUENCES, in the owner of the sequence or the current schema
+func (m Migrator) HasSequence(value interface{}, name string) bool {
+	var count int64
+
+	m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		owner, sequence := m.CurrentSchema(stmt, name)
+		return m.DB.Raw("SELECT COUNT(*) FROM ALL_SEQUENCES WHERE SEQUENCE_OWNER = ? AND SEQUENCE_NAME = ?", owner, sequence).Row().Scan(&count)
+	})
+
+	return count > 0
+}
+
+// AlterSequence alters the options of the sequence except the start value, see: ResetSequence
+func (m Migrator) AlterSequence(value interface{}, name string, opts SequenceOptions) error {
+	options := opts.clause()
+	if options == "" {
+		return nil
+	}
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Exec("ALTER SEQUENCE ?"+options, clause.Table{Name: name}).Error
+	})
+}
+
+// ResetSequence restarts the sequence with the start value of opts, e.g.: the MAX value of the
+// column after the data are imported. The sequence is altered by ALTER SEQUENCE ... RESTART since 18c,
+// otherwise it is dropped and created again, the privileges granted on it are dropped as well.
+func (m Migrator) ResetSequence(value interface{}, name string, opts SequenceOptions) error {
+	if !m.Dialector.Config.capabilities.SequenceRestart {
+		// the start value is read before the sequence is dropped, so that it is not lost by the
+		// failure of reading the MAX value
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) (err error) {
+			opts.StartWith, err = m.sequenceStartWith(stmt, opts)
+			opts.StartWithMaxOf = ""
+			return err
+		}); err != nil {
+			return err
+		}
+		if err := m.DropSequence(value, name); err != nil {
+			return err
+		}
+		return m.CreateSequence(value, name, opts)
+	}
+
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		start, err := m.sequenceStart(stmt, opts)
+		if err != nil {
+			return err
+		}
+		return m.DB.Exec("ALTER SEQUENCE ? RESTART"+start+opts.clause(), clause.Table{Name: name}).Error
+	})
+}
+
+// sequenceStart returns the START WITH clause of opts, the start value is a literal,
+// since the bind variables are not allowed in DDL.
+func (m Migrator) sequenceStart(stmt *gorm.Statement, opts SequenceOptions) (string, error) {
+	start, err := m.sequenceStartWith(stmt, opts)
+	if err != nil || start == 0 {
+		return "", err
+	}
+	return " START WITH " + strconv.FormatInt(start, 10), nil
+}
+
+// sequenceStartWith returns the start value of opts, which is read from the table by StartWithMaxOf
+func (m Migrator) sequenceStartWith(stmt *gorm.Statement, opts SequenceOptions) (int64, error) {
+	if opts.StartWithMaxOf == "" {
+		return opts.StartWith, nil
+	}
+
+	column := opts.StartWithMaxOf
+	if field := stmt.Schema.LookUpField(column); field != nil {
+		column = field.DBName
+	}
+
+	var max int64
+	if err := m.DB.Raw(
+		"SELECT COALESCE(MAX(?), 0) FROM ?", clause.Column{Name: column}, clause.Table{Name: stmt.Table},
+	).Row().Scan(&max); err != nil {
+		return 0, err
+	}
+
+	increment := opts.IncrementBy
+	if increment == 0 {
+		increment = 1
+	}
+	return max + increment, nil
+}
+
+// AutoMigrate migrates the tables by gorm, and creates the sequences of the `sequence` tags
+// which do not exist, starting with the MAX value of their columns, e.g.:
+//
+//	CustomerID int64 `gorm:"column:CUSTOMER_ID;sequence:CUSTOMERS_S"`
+func (m Migrator) AutoMigrate(values ...interface{}) error {
+	if err := m.Migrator.AutoMigrate(values...); err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			for _, field := range sequenceFields(stmt.Schema) {
+				name := field.TagSettings["SEQUENCE"]
+				if m.HasSequence(value, name) {
+					continue
+				}
+				if err := m.CreateSequence(value, name, SequenceOptions{StartWithMaxOf: field.DBName}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package oracle
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// sessionParameter is a parameter set by ALTER SESSION
+type sessionParameter struct {
+	// name is the name of the parameter, e.g.: NLS_DATE_FORMAT
+	name string
+	// value is the value in SQL, e.g.: 'YYYY-MM-DD HH24:MI:SS'
+	value string
+	// expected is the value read back from the session, empty if it can not be verified
+	expected string
+}
+
+// sessionParameters returns the parameters of the session configured by Config
+func (c *Config) sessionParameters() ([]sessionParameter, error) {
+	var params []sessionParameter
+	for _, p := range []struct{ name, value string }{
+		{"NLS_DATE_FORMAT", c.NLSDateFormat},
+		{"NLS_TIMESTAMP_FORMAT", c.NLSTimestampFormat},
+		{"NLS_TIMESTAMP_TZ_FORMAT", c.NLSTimestampTZFormat},
+		{"NLS_NUMERIC_CHARACTERS", c.NLSNumericCharacters},
+		{"NLS_SORT", c.NLSSort},
+		{"NLS_COMP", c.NLSComp},
+	} {
+		if p.value != "" {
+			params = append(params, sessionParameter{name: p.name, value: quoteString(p.value), expected: p.value})
+		}
+	}
+
+	if c.TimeZone != "" {
+		switch strings.ToUpper(c.TimeZone) {
+		case "LOCAL", "DBTIMEZONE":
+			// the keywords are resolved to the offsets by the server, which can not be verified
+			params = append(params, sessionParameter{name: "TIME_ZONE", value: strings.ToUpper(c.TimeZone)})
+		default:
+			params = append(params, sessionParameter{name: "TIME_ZONE", value: quoteString(c.TimeZone), expected: c.TimeZone})
+		}
+	}
+
+	if c.CurrentSchema != "" {
+		schema, err := schemaIdentifier(c.CurrentSchema)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CurrentSchema: %w", err)
+		}
+		params = append(params, sessionParameter{name: "CURRENT_SCHEMA", value: schema, expected: dictionaryName(schema)})
+	}
+
+	return params, nil
+}
+
+// InitStatements returns the statements executed on every new connection of the pool,
+// which are the ALTER SESSION statement of the NLS, time zone and schema settings of Config,
+// followed by Config.SessionStatements.
+func (c *Config) InitStatements() ([]string, error) {
+	params, err := c.sessionParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []string
+	if len(params) > 0 {
+		settings := make([]string, len(params))
+		for idx, p := range params {
+			settings[idx] = p.name + " = " + p.value
+		}
+		statements = append(statements, "ALTER SESSION SET "+strings.Join(settings, " "))
+	}
+	for _, stmt := range c.SessionStatements {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements, nil
+}
+
+// verifySession reads the session parameters back, so that the settings silently
+// ignored by the server or overwritten by a logon trigger are reported.
+func (c *Config) verifySession(ctx context.Context, pool gorm.ConnPool) error {
+	params, err := c.sessionParameters()
+	if err != nil || len(params) == 0 {
+		return err
+	}
+
+	actual := map[string]string{}
+	rows, err := pool.QueryContext(ctx, "SELECT PARAMETER, VALUE FROM NLS_SESSION_PARAMETERS")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, value string
+		if err = rows.Scan(&name, &value); err != nil {
+			return err
+		}
+		actual[name] = value
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	var timeZone, currentSchema string
+	if err = pool.QueryRowContext(ctx, "SELECT SESSIONTIMEZONE, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL").Scan(&timeZone, &currentSchema); err != nil {
+		return err
+	}
+	actual["TIME_ZONE"] = timeZone
+	actual["CURRENT_SCHEMA"] = currentSchema
+
+	for _, p := range params {
+		if p.expected == "" {
+			continue
+		}
+		value, expected := actual[p.name], p.expected
+		if p.name == "TIME_ZONE" {
+			value, expected = normalizeTimeZone(value), normalizeTimeZone(expected)
+		}
+		if !strings.EqualFold(value, expected) {
+			return fmt.Errorf("session parameter %s is %q, but %q expected", p.name, actual[p.name], p.expected)
+		}
+	}
+	return nil
+}
+
+// timeZoneOffsetPattern matches the time zone offsets, e.g.: +8:00, -03:30
+var timeZoneOffsetPattern = regexp.MustCompile(`^([+-])(\d{1,2}):(\d{2})$`)
+
+// normalizeTimeZone pads the hours of the time zone offset, e.g.: +8:00 to +08:00,
+// since SESSIONTIMEZONE is read as +08:00 whichever is set. The region names are
+// returned as they are, e.g.: Asia/Shanghai.
+func normalizeTimeZone(tz string) string {
+	tz = strings.TrimSpace(tz)
+	if matches := timeZoneOffsetPattern.FindStringSubmatch(tz); matches != nil {
+		if len(matches[2]) == 1 {
+			matches[2] = "0" + matches[2]
+		}
+		return matches[1] + matches[2] + ":" + matches[3]
+	}
+	return tz
+}
+
+// execSession executes the statements on the new connection of driver
+func execSession(ctx context.Context, dc driver.Conn, statements []string) error {
+	for _, stmt := range statements {
+		var err error
+		if execer, ok := dc.(driver.ExecerContext); ok {
+			_, err = execer.ExecContext(ctx, stmt, nil)
+		} else {
+			err = execPrepared(ctx, dc, stmt)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", stmt, err)
+		}
+	}
+	return nil
+}
+
+func execPrepared(ctx context.Context, dc driver.Conn, query string) error {
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+	if preparer, ok := dc.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = dc.Prepare(query)
+	}
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if execer, ok := stmt.(driver.StmtExecContext); ok {
+		_, err = execer.ExecContext(ctx, nil)
+	} else {
+		_, err = stmt.Exec(nil)
+	}
+	return err
+}
+
+// quoteString quotes str as a string literal of SQL
+func quoteString(str string) string {
+	return "'" + strings.ReplaceAll(str, "'", "''") + "'"
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+)
+
+type AlteredCustomer struct {
+	ID      int64   `gorm:"primaryKey"`
+	Name    string  `gorm:"size:3000"`
+	Remark  string  `gorm:"size:3000"`
+	Balance float64 `gorm:"precision:10;scale:2"`
+}
+
+func (AlteredCustomer) TableName() string {
+	return "ALTERED_CUSTOMERS"
+}
+
+type AlteredCustomerV2 struct {
+	ID      int64   `gorm:"primaryKey"`
+	Name    string  `gorm:"size:3500;not null;default:'N/A'"`
+	Remark  string  `gorm:"size:5000"`
+	Balance float64 `gorm:"precision:8;scale:2"`
+}
+
+func (AlteredCustomerV2) TableName() string {
+	return "ALTERED_CUSTOMERS"
+}
+
+func TestFullDataTypeOfSQL(t *testing.T) {
+	db, recorder := getDryRunDbWithRecorder(t, version19c)
+
+	if err := db.Migrator().CreateTable(&AlteredCustomerV2{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.sqls) != 1 {
+		t.Fatalf("unexpected SQL: %q", recorder.sqls)
+	}
+
+	// the default value is followed by the constraints
+	for _, expected := range []string{"NAME VARCHAR2(3500) DEFAULT 'N/A' NOT NULL", "BALANCE NUMBER(8, 2)"} {
+		if !strings.Contains(recorder.sqls[0], expected) {
+			t.Errorf("unexpected SQL: %s, expected: %s", recorder.sqls[0], expected)
+		}
+	}
+}
+
+func TestAlterColumn(t *testing.T) {
+	db := getDb(t)
+
+	migrator := db.Migrator()
+	if migrator.HasTable(&AlteredCustomer{}) {
+		if err := migrator.DropTable(&AlteredCustomer{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := migrator.CreateTable(&AlteredCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+	defer migrator.DropTable(&AlteredCustomer{})
+
+	if err := db.Create(&AlteredCustomer{ID: 1, Name: "alice", Remark: "the first one", Balance: 12.5}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	column := func(name string) (dataType string, nullable bool, defaultValue string) {
+		columnTypes, err := migrator.ColumnTypes(&AlteredCustomer{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, columnType := range columnTypes {
+			if columnType.Name() == name {
+				dataType, _ = columnType.ColumnType()
+				nullable, _ = columnType.Nullable()
+				defaultValue, _ = columnType.DefaultValue()
+				return
+			}
+		}
+		t.Fatalf("column %s not found", name)
+		return
+	}
+
+	// the length, default value and nullability are changed by MODIFY
+	if err := migrator.AlterColumn(&AlteredCustomerV2{}, "Name"); err != nil {
+		t.Fatal(err)
+	}
+	if dataType, nullable, defaultValue := column("NAME"); dataType != "VARCHAR2(3500)" || nullable || defaultValue != "N/A" {
+		t.Errorf("unexpected NAME: %s, %v, %s", dataType, nullable, defaultValue)
+	}
+	// nothing is changed, ORA-01442 is not raised
+	if err := migrator.AlterColumn(&AlteredCustomerV2{}, "Name"); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrator.AlterColumn(&AlteredCustomer{}, "Name"); err != nil {
+		t.Fatal(err)
+	}
+	if dataType, nullable, defaultValue := column("NAME"); dataType != "VARCHAR2(3000)" || !nullable || defaultValue != "" {
+		t.Errorf("unexpected NAME: %s, %v, %s", dataType, nullable, defaultValue)
+	}
+
+	// the existing temporary column is not reused
+	if err := db.Exec("ALTER TABLE ALTERED_CUSTOMERS ADD (REMARK_TMP VARCHAR2(10))").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := migrator.AlterColumn(&AlteredCustomerV2{}, "Remark"); err == nil || !strings.Contains(err.Error(), "REMARK_TMP") {
+		t.Errorf("error of the existing temporary column expected, got: %v", err)
+	}
+	if dataType, _, _ := column("REMARK"); dataType != "VARCHAR2(3000)" {
+		t.Errorf("unexpected REMARK: %s", dataType)
+	}
+	if err := db.Exec("ALTER TABLE ALTERED_CUSTOMERS DROP COLUMN REMARK_TMP").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// VARCHAR2 to CLOB by add-copy-drop-rename
+	if err := migrator.AlterColumn(&AlteredCustomerV2{}, "Remark"); err != nil {
+		t.Fatal(err)
+	}
+	if dataType, _, _ := column("REMARK"); dataType != "CLOB" {
+		t.Errorf("unexpected REMARK: %s", dataType)
+	}
+	var customer AlteredCustomer
+	if err := db.First(&customer, 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if customer.Remark != "the first one" {
+		t.Errorf("unexpected REMARK: %s", customer.Remark)
+	}
+
+	// the precision can not be decreased with the existing data
+	if err := migrator.AlterColumn(&AlteredCustomerV2{}, "Balance"); !errors.Is(err, oracle.ErrColumnNarrowed) {
+		t.Errorf("ErrColumnNarrowed expected, got: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+)
+
+func TestAppContextFromContext(t *testing.T) {
+	ctx := oracle.WithAppContext(context.Background(), "APP_CTX", "TENANT_ID", "42")
+	ctx = oracle.WithAppContext(ctx, "APP_CTX", "REGION", "EU")
+	ctx = oracle.WithAppContext(ctx, "app_ctx", "tenant_id", "43")
+
+	expected := []oracle.AppContext{
+		{Namespace: "APP_CTX", Attribute: "REGION", Value: "EU"},
+		{Namespace: "app_ctx", Attribute: "tenant_id", Value: "43"},
+	}
+	if attrs := oracle.AppContextsFromContext(ctx); !reflect.DeepEqual(attrs, expected) {
+		t.Errorf("unexpected application context: %+v, expected: %+v", attrs, expected)
+	}
+}
+
+func TestAppContextStatements(t *testing.T) {
+	sessionDriver.reset(nil)
+	db, err := gorm.Open(oracle.New(oracle.Config{
+		DriverName:                sessionDriverName,
+		DSN:                       "app_context",
+		SkipInitializeWithVersion: true,
+		AppContextPackage:         "APP.APP_CTX_PKG",
+	}), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	ctx := oracle.WithAppContext(context.Background(), "APP_CTX", "TENANT_ID", "42")
+	if err = db.WithContext(ctx).Exec("UPDATE ORDERS SET STATUS = 'CLOSED'").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// the statements are executed on the same connection
+	expected := []string{
+		"BEGIN APP.APP_CTX_PKG.SET_CONTEXT(:1, :2, :3); END;",
+		"UPDATE ORDERS SET STATUS = 'CLOSED'",
+		"BEGIN APP.APP_CTX_PKG.CLEAR_CONTEXT(:1, NULL, :2); END;",
+	}
+	sessions := sessionDriver.sessions()
+	if len(sessions) != 1 {
+		t.Fatalf("1 connection expected, got %d", len(sessions))
+	}
+	if !reflect.DeepEqual(sessions[0], expected) {
+		t.Errorf("unexpected statements: %q, expected: %q", sessions[0], expected)
+	}
+}
+
+func TestAppContextInvalidPackage(t *testing.T) {
+	_, err := gorm.Open(oracle.New(oracle.Config{
+		DSN:                       dsn,
+		SkipInitializeWithVersion: true,
+		AppContextPackage:         "APP_CTX_PKG; DROP TABLE ORDERS",
+	}), &gorm.Config{DisableAutomaticPing: true})
+	if err == nil {
+		t.Fatalf("error expected for the invalid package")
+	}
+}
+
+type TenantCustomer struct {
+	ID     int64         `gorm:"primaryKey;autoIncrement:false"`
+	Orders []TenantOrder `gorm:"foreignKey:CustomerID"`
+}
+
+type TenantOrder struct {
+	ID         int64 `gorm:"primaryKey;autoIncrement:false"`
+	CustomerID int64
+}
+
+func TestAppContextNestedStatements(t *testing.T) {
+	sessionDriver.reset(nil)
+	sessionDriver.results = map[string]valueRows{
+		"TENANT_CUSTOMERS": {columns: []string{"ID"}, values: [][]driver.Value{{int64(1)}}},
+		"TENANT_ORDERS":    {columns: []string{"ID", "CUSTOMER_ID"}, values: [][]driver.Value{{int64(10), int64(1)}}},
+	}
+	db, err := gorm.Open(oracle.New(oracle.Config{
+		DriverName:                sessionDriverName,
+		DSN:                       "app_context",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	ctx := oracle.WithAppContext(context.Background(), "APP_CTX", "TENANT_ID", "42")
+
+	// the orders are preloaded on the connection with the attributes set
+	var customers []TenantCustomer
+	if err = db.WithContext(ctx).Preload("Orders").Find(&customers).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(customers) != 1 || len(customers[0].Orders) != 1 {
+		t.Errorf("unexpected customers: %+v", customers)
+	}
+
+	// the orders are created as the associations in the default transaction on the same connection
+	customer := TenantCustomer{ID: 2, Orders: []TenantOrder{{ID: 20}}}
+	if err = db.WithContext(ctx).Create(&customer).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	sessions := sessionDriver.sessions()
+	if len(sessions) != 1 {
+		t.Fatalf("1 connection expected, got %d", len(sessions))
+	}
+	var sets, clears []int
+	for idx, stmt := range sessions[0] {
+		switch {
+		case strings.Contains(stmt, "SET_CONTEXT"):
+			sets = append(sets, idx)
+		case strings.Contains(stmt, "CLEAR_CONTEXT"):
+			clears = append(clears, idx)
+		}
+	}
+	// set and cleared once for each of Find and Create, the others are between them
+	statements := len(sessions[0])
+	if len(sets) != 2 || len(clears) != 2 || sets[0] != 0 || clears[1] != statements-1 || clears[0]+1 != sets[1] ||
+		sessions[0][statements-2] != "COMMIT" {
+		t.Errorf("unexpected statements: %q", sessions[0])
+	}
+}
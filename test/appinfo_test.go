@@ -0,0 +1,97 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+)
+
+func TestSessionInfoFromContext(t *testing.T) {
+	ctx := oracle.WithClientIdentifier(context.Background(), "alice")
+	ctx = oracle.WithModule(ctx, "billing", "close_invoice")
+	ctx = oracle.WithClientInfo(ctx, "web-1")
+
+	expected := oracle.SessionInfo{ClientIdentifier: "alice", Module: "billing", Action: "close_invoice", ClientInfo: "web-1"}
+	if info := oracle.SessionInfoFromContext(ctx); info != expected {
+		t.Errorf("unexpected session info: %+v, expected: %+v", info, expected)
+	}
+
+	expected.Action = "void_invoice"
+	if info := oracle.SessionInfoFromContext(oracle.WithAction(ctx, "void_invoice")); info != expected {
+		t.Errorf("unexpected session info: %+v, expected: %+v", info, expected)
+	}
+
+	if info := oracle.SessionInfoFromContext(context.Background()); info != (oracle.SessionInfo{}) {
+		t.Errorf("empty session info expected, got: %+v", info)
+	}
+}
+
+func TestSessionInfoUnwrapped(t *testing.T) {
+	ctx := oracle.WithModule(context.Background(), "billing", "close_invoice")
+
+	fakeDb, pool := getFakeDb(t)
+	if err := fakeDb.WithContext(ctx).Exec("UPDATE INVOICES SET STATUS = 'CLOSED'").Error; !errors.Is(err, oracle.ErrSessionInfoNotSet) {
+		t.Errorf("ErrSessionInfoNotSet expected with Config.Conn, got: %v", err)
+	}
+	if err := fakeDb.WithContext(ctx).Create(&CustomerWithPrimaryKey{CustomerName: "alice"}).Error; !errors.Is(err, oracle.ErrSessionInfoNotSet) {
+		t.Errorf("ErrSessionInfoNotSet expected with Config.Conn, got: %v", err)
+	}
+	if len(pool.execs) != 0 || pool.begins != 0 {
+		t.Errorf("the statements are executed without the session info: %q, begins: %d", pool.execs, pool.begins)
+	}
+	// the statements without SessionInfo are not affected
+	if err := fakeDb.Exec("UPDATE INVOICES SET STATUS = 'CLOSED'").Error; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var rows []CustomerWithPrimaryKey
+	db := getDryRunDb(t, version19c)
+	if err := db.Session(&gorm.Session{PrepareStmt: true}).WithContext(ctx).Find(&rows).Error; !errors.Is(err, oracle.ErrSessionInfoNotSet) {
+		t.Errorf("ErrSessionInfoNotSet expected with PrepareStmt, got: %v", err)
+	}
+	if err := db.WithContext(ctx).Find(&rows).Error; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSessionInfo(t *testing.T) {
+	db := getDb(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the attributes are set and cleared on the same connection
+	sqlDB.SetMaxOpenConns(1)
+
+	const query = "SELECT SYS_CONTEXT('USERENV', 'CLIENT_IDENTIFIER') AS CLIENT_IDENTIFIER, SYS_CONTEXT('USERENV', 'MODULE') AS MODULE, " +
+		"SYS_CONTEXT('USERENV', 'ACTION') AS ACTION, SYS_CONTEXT('USERENV', 'CLIENT_INFO') AS CLIENT_INFO FROM DUAL"
+
+	expected := oracle.SessionInfo{ClientIdentifier: "alice", Module: "billing", Action: "close_invoice", ClientInfo: "web-1"}
+	ctx := oracle.WithSessionInfo(context.Background(), expected)
+
+	var info oracle.SessionInfo
+	if err = db.WithContext(ctx).Raw(query).Scan(&info).Error; err != nil {
+		t.Fatal(err)
+	}
+	if info != expected {
+		t.Errorf("unexpected session info: %+v, expected: %+v", info, expected)
+	}
+
+	expected.Action = "void_invoice"
+	if err = db.WithContext(oracle.WithAction(ctx, "void_invoice")).Raw(query).Scan(&info).Error; err != nil {
+		t.Fatal(err)
+	}
+	if info != expected {
+		t.Errorf("unexpected session info: %+v, expected: %+v", info, expected)
+	}
+
+	if err = db.Raw(query).Scan(&info).Error; err != nil {
+		t.Fatal(err)
+	}
+	if info.ClientIdentifier != "" || info.Action != "" || info.ClientInfo != "" {
+		t.Errorf("session info is not cleared: %+v", info)
+	}
+}
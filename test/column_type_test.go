@@ -0,0 +1,101 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+)
+
+type ColumnTypedCustomer struct {
+	ID      int64   `gorm:"primaryKey;autoIncrement"`
+	Code    string  `gorm:"size:20;unique"`
+	Name    string  `gorm:"type:VARCHAR2(100 CHAR);default:'N/A'"`
+	Balance float64 `gorm:"precision:10;scale:2;not null;default:0"`
+}
+
+func TestColumnTypes(t *testing.T) {
+	db := getDb(t)
+
+	migrator := db.Migrator()
+	if migrator.HasTable(&ColumnTypedCustomer{}) {
+		if err := migrator.DropTable(&ColumnTypedCustomer{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := migrator.CreateTable(&ColumnTypedCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+	defer migrator.DropTable(&ColumnTypedCustomer{})
+
+	// the hidden virtual column of the function-based index is excluded
+	if err := db.Exec("CREATE INDEX IDX_COLUMN_TYPED_CUSTOMERS_UPPER ON COLUMN_TYPED_CUSTOMERS (UPPER(NAME))").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	columnTypes, err := migrator.ColumnTypes(&ColumnTypedCustomer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type column struct {
+		Name          string
+		Type          string
+		Length        int64
+		Nullable      bool
+		PrimaryKey    bool
+		Unique        bool
+		AutoIncrement bool
+		Default       string
+		HasDefault    bool
+	}
+	expected := []column{
+		{Name: "ID", Type: "NUMBER", PrimaryKey: true, AutoIncrement: true},
+		{Name: "CODE", Type: "CHAR(20)", Length: 20, Nullable: true, Unique: true},
+		{Name: "NAME", Type: "VARCHAR2(100 CHAR)", Length: 100, Nullable: true, Default: "N/A", HasDefault: true},
+		{Name: "BALANCE", Type: "NUMBER(10,2)", Default: "0", HasDefault: true},
+	}
+	if len(columnTypes) != len(expected) {
+		t.Fatalf("%d columns expected, got %d", len(expected), len(columnTypes))
+	}
+	for idx, columnType := range columnTypes {
+		var actual column
+		actual.Name = columnType.Name()
+		actual.Type, _ = columnType.ColumnType()
+		actual.Length, _ = columnType.Length()
+		actual.Nullable, _ = columnType.Nullable()
+		actual.PrimaryKey, _ = columnType.PrimaryKey()
+		actual.Unique, _ = columnType.Unique()
+		actual.AutoIncrement, _ = columnType.AutoIncrement()
+		actual.Default, actual.HasDefault = columnType.DefaultValue()
+		if actual != expected[idx] {
+			t.Errorf("unexpected column: %+v, expected: %+v", actual, expected[idx])
+		}
+	}
+
+	if precision, scale, ok := columnTypes[3].DecimalSize(); !ok || precision != 10 || scale != 2 {
+		t.Errorf("unexpected decimal size of BALANCE: %d, %d", precision, scale)
+	}
+}
+
+func TestColumnTypesSQL(t *testing.T) {
+	for version, userGenerated := range map[string]bool{version11g: false, version19c: true} {
+		db, recorder := getDryRunDbWithRecorder(t, version)
+
+		// the columns are read from the data dictionary before the DryRun mode stops the query
+		if _, err := db.Scopes(oracle.WithSchema("APP")).Migrator().ColumnTypes(&ColumnTypedCustomer{}); !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+			t.Errorf("unexpected error of %s: %v", version, err)
+		}
+		if len(recorder.sqls) != 1 || !strings.Contains(recorder.sqls[0], "ALL_TAB_COLS") {
+			t.Fatalf("unexpected SQL of %s: %q", version, recorder.sqls)
+		}
+		if strings.Contains(recorder.sqls[0], "USER_GENERATED") != userGenerated {
+			t.Errorf("unexpected SQL of %s: %s", version, recorder.sqls[0])
+		}
+		if strings.Contains(recorder.sqls[0], "ALL_TAB_IDENTITY_COLS") != userGenerated {
+			t.Errorf("unexpected SQL of %s: %s", version, recorder.sqls[0])
+		}
+	}
+}
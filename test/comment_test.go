@@ -0,0 +1,89 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm/migrator"
+)
+
+type CommentedCustomer struct {
+	ID   int64  `gorm:"primaryKey;comment:the id"`
+	Name string `gorm:"size:100;comment:customer's name"`
+	City string `gorm:"size:100"`
+}
+
+func (CommentedCustomer) TableComment() string {
+	return "the customers"
+}
+
+func TestCommentCreateTableSQL(t *testing.T) {
+	db, recorder := getDryRunDbWithRecorder(t, version19c)
+
+	if err := db.Migrator().CreateTable(&CommentedCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"COMMENT ON TABLE COMMENTED_CUSTOMERS IS 'the customers'",
+		"COMMENT ON COLUMN COMMENTED_CUSTOMERS.ID IS 'the id'",
+		"COMMENT ON COLUMN COMMENTED_CUSTOMERS.NAME IS 'customer''s name'",
+	}
+	if len(recorder.sqls) != len(expected)+1 {
+		t.Fatalf("unexpected SQL: %q", recorder.sqls)
+	}
+	if sqls := recorder.sqls[1:]; !reflect.DeepEqual(sqls, expected) {
+		t.Errorf("unexpected SQL: %q, expected: %q", sqls, expected)
+	}
+}
+
+func TestCommentAddColumnSQL(t *testing.T) {
+	db, recorder := getDryRunDbWithRecorder(t, version19c)
+
+	if err := db.Migrator().AddColumn(&CommentedCustomer{}, "Name"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(recorder.sqls) != 2 {
+		t.Fatalf("unexpected SQL: %q", recorder.sqls)
+	}
+	if expected := "COMMENT ON COLUMN COMMENTED_CUSTOMERS.NAME IS 'customer''s name'"; recorder.sqls[1] != expected {
+		t.Errorf("unexpected SQL: %s, expected: %s", recorder.sqls[1], expected)
+	}
+}
+
+func TestCommentMigrateColumnSQL(t *testing.T) {
+	db, recorder := getDryRunDbWithRecorder(t, version19c)
+
+	stmt := db.Model(&CommentedCustomer{}).Statement
+	if err := stmt.Parse(&CommentedCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		field    string
+		comment  string
+		expected []string
+	}{
+		{"Name", "customer's name", nil},
+		{"Name", "the name", []string{"COMMENT ON COLUMN COMMENTED_CUSTOMERS.NAME IS 'customer''s name'"}},
+		{"City", "the city", []string{"COMMENT ON COLUMN COMMENTED_CUSTOMERS.CITY IS ''"}},
+	} {
+		recorder.sqls = nil
+		field := stmt.Schema.LookUpField(c.field)
+		columnType := migrator.ColumnType{}
+		columnType.NameValue.String, columnType.NameValue.Valid = field.DBName, true
+		columnType.DataTypeValue.String, columnType.DataTypeValue.Valid = "CHAR", true
+		columnType.LengthValue.Int64, columnType.LengthValue.Valid = 100, true
+		columnType.DecimalSizeValue.Valid = true
+		columnType.NullableValue.Bool, columnType.NullableValue.Valid = true, true
+		columnType.CommentValue.String, columnType.CommentValue.Valid = c.comment, true
+
+		if err := db.Migrator().MigrateColumn(&CommentedCustomer{}, field, columnType); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(recorder.sqls, c.expected) {
+			t.Errorf("unexpected SQL of %s: %q, expected: %q", c.field, recorder.sqls, c.expected)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sijms/go-ora/v2/network"
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+)
+
+func TestAsError(t *testing.T) {
+	cases := []struct {
+		err        error
+		code       int
+		constraint string
+		sentinel   error
+		retryable  bool
+	}{
+		{&network.OracleError{ErrCode: 1, ErrMsg: "ORA-00001: unique constraint (APP.PK_CUSTOMERS) violated"}, 1, "APP.PK_CUSTOMERS", oracle.ErrDuplicatedKey, false},
+		{&network.OracleError{ErrCode: 2291, ErrMsg: "ORA-02291: integrity constraint (APP.FK_ORDERS) violated - parent key not found"}, 2291, "APP.FK_ORDERS", oracle.ErrForeignKeyViolated, false},
+		{&network.OracleError{ErrCode: 2292, ErrMsg: "ORA-02292: integrity constraint (APP.FK_ORDERS) violated - child record found"}, 2292, "APP.FK_ORDERS", oracle.ErrForeignKeyViolated, false},
+		{&network.OracleError{ErrCode: 1403, ErrMsg: "ORA-01403: no data found"}, 1403, "", gorm.ErrRecordNotFound, false},
+		{fmt.Errorf("exec failed: %w", &network.OracleError{ErrCode: 60, ErrMsg: "ORA-00060: deadlock detected while waiting for resource"}), 60, "", nil, true},
+		{errors.New("ORA-03113: end-of-file on communication channel"), 3113, "", nil, true},
+	}
+
+	for _, c := range cases {
+		e, ok := oracle.AsError(c.err)
+		if !ok {
+			t.Errorf("%v is not translated", c.err)
+			continue
+		}
+		if e.Code != c.code || e.Constraint != c.constraint || e.Retryable() != c.retryable {
+			t.Errorf("unexpected error of %v: %+v, retryable: %v", c.err, e, e.Retryable())
+		}
+		if c.sentinel != nil && !errors.Is(e, c.sentinel) {
+			t.Errorf("%v is expected to be %v", c.err, c.sentinel)
+		}
+		if e.Error() != c.err.Error() {
+			t.Errorf("message of %v is changed: %s", c.err, e.Error())
+		}
+	}
+
+	if _, ok := oracle.AsError(errors.New("not an oracle error")); ok {
+		t.Errorf("error is translated unexpectedly")
+	}
+}
+
+func TestDuplicatedKey(t *testing.T) {
+	db := getDb(t).Begin()
+	defer db.Rollback()
+
+	row := getCustomerWithPrimaryKey("TestDuplicatedKey")
+	checkTxError(t, db.Create(&row))
+
+	err := db.Exec("INSERT INTO CUSTOMERS (CUSTOMER_ID, CUSTOMER_NAME) VALUES (?, ?)", row.CustomerID, row.CustomerName).Error
+	if !errors.Is(err, oracle.ErrDuplicatedKey) {
+		t.Errorf("ErrDuplicatedKey expected, but got %v", err)
+	}
+}
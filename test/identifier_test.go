@@ -0,0 +1,64 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestShortenIdentifier(t *testing.T) {
+	name := "IDX_CUSTOMER_ORDERS_CREATED_TIME"
+
+	shortened := oracle.ShortenIdentifier(name, 30)
+	if len(shortened) != 30 || !strings.HasPrefix(shortened, "IDX_CUSTOMER_ORDERS_C_") {
+		t.Errorf("unexpected shortened identifier %q", shortened)
+	}
+	if again := oracle.ShortenIdentifier(name, 30); again != shortened {
+		t.Errorf("identifier is not shortened deterministically: %q, %q", shortened, again)
+	}
+	if other := oracle.ShortenIdentifier("IDX_CUSTOMER_ORDERS_CREATED_USER", 30); other == shortened {
+		t.Errorf("different identifiers are shortened to the same %q", other)
+	}
+	if unchanged := oracle.ShortenIdentifier(name, 128); unchanged != name {
+		t.Errorf("identifier %q is shortened unexpectedly: %q", name, unchanged)
+	}
+	if quoted := oracle.ShortenIdentifier(`"`+name+`"`, 30); quoted != `"`+shortened+`"` {
+		t.Errorf("unexpected shortened quoted identifier %q", quoted)
+	}
+}
+
+func TestShortenIdentifierSQL(t *testing.T) {
+	alias := "Department__DEPARTMENT_LONG_NAME"
+
+	for version, expected := range map[string]string{
+		version11g: "SELECT NAME AS " + oracle.ShortenIdentifier(alias, 30) + " FROM Customers",
+		version19c: "SELECT NAME AS " + alias + " FROM Customers",
+	} {
+		db := getDryRunDb(t, version)
+
+		var rows []map[string]interface{}
+		tx := checkTxError(t, db.Table("Customers").
+			Clauses(clause.Select{Columns: []clause.Column{{Name: "NAME", Alias: alias}}}).Find(&rows))
+
+		if sql := tx.Statement.SQL.String(); sql != expected {
+			t.Errorf("unexpected SQL of %s:\n%s\nexpected:\n%s", version, sql, expected)
+		}
+	}
+}
+
+func TestShortenIdentifierUnwrappedSQL(t *testing.T) {
+	alias := "Department__DEPARTMENT_LONG_NAME"
+	db := getDryRunDb(t, version11g).Session(&gorm.Session{PrepareStmt: true})
+
+	// the shortened aliases can not be restored by the prepared statements
+	var rows []map[string]interface{}
+	if tx := db.Table("Customers").Clauses(clause.Select{Columns: []clause.Column{{Name: "NAME", Alias: alias}}}).Find(&rows); tx.Error == nil {
+		t.Errorf("error expected for the shortened alias with PrepareStmt")
+	}
+
+	// the aliases not shortened are allowed
+	checkTxError(t, db.Table("Customers").Clauses(clause.Select{Columns: []clause.Column{{Name: "NAME", Alias: "DEPARTMENT_NAME"}}}).Find(&rows))
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+)
+
+type IndexedCustomer struct {
+	ID    int64  `gorm:"primaryKey"`
+	Email string `gorm:"size:100;uniqueIndex"`
+	Name  string `gorm:"size:100;index:IDX_INDEXED_CUSTOMERS_NAME_CITY,priority:1"`
+	City  string `gorm:"size:100;index:IDX_INDEXED_CUSTOMERS_NAME_CITY,priority:2"`
+}
+
+func TestGetIndexes(t *testing.T) {
+	db := getDb(t)
+
+	migrator := db.Migrator()
+	if migrator.HasTable(&IndexedCustomer{}) {
+		if err := migrator.DropTable(&IndexedCustomer{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := migrator.CreateTable(&IndexedCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+	defer migrator.DropTable(&IndexedCustomer{})
+
+	if err := db.Exec("CREATE INDEX IDX_INDEXED_CUSTOMERS_UPPER ON INDEXED_CUSTOMERS (UPPER(NAME), CITY DESC)").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	indexes, err := migrator.GetIndexes(&IndexedCustomer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type index struct {
+		Columns    []string
+		PrimaryKey bool
+		Unique     bool
+		Type       string
+	}
+	expected := map[string]index{
+		"IDX_INDEXED_CUSTOMERS_EMAIL":     {Columns: []string{"EMAIL"}, Unique: true, Type: "NORMAL"},
+		"IDX_INDEXED_CUSTOMERS_NAME_CITY": {Columns: []string{"NAME", "CITY"}, Type: "NORMAL"},
+		"IDX_INDEXED_CUSTOMERS_UPPER":     {Columns: []string{`UPPER("NAME")`, "CITY"}, Type: "FUNCTION-BASED NORMAL"},
+	}
+	for _, idx := range indexes {
+		primaryKey, _ := idx.PrimaryKey()
+		unique, _ := idx.Unique()
+		actual := index{Columns: idx.Columns(), PrimaryKey: primaryKey, Unique: unique, Type: idx.(*oracle.Index).Type()}
+
+		if primaryKey {
+			// the name of primary key is generated by Oracle, e.g.: SYS_C008283
+			if !reflect.DeepEqual(actual, index{Columns: []string{"ID"}, PrimaryKey: true, Unique: true, Type: "NORMAL"}) {
+				t.Errorf("unexpected primary key %s: %+v", idx.Name(), actual)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(actual, expected[idx.Name()]) {
+			t.Errorf("unexpected index %s: %+v, expected: %+v", idx.Name(), actual, expected[idx.Name()])
+		}
+		delete(expected, idx.Name())
+	}
+	if len(expected) > 0 {
+		t.Errorf("indexes not found: %+v", expected)
+	}
+
+	if !migrator.HasIndex(&IndexedCustomer{}, "IDX_INDEXED_CUSTOMERS_NAME_CITY") {
+		t.Errorf("IDX_INDEXED_CUSTOMERS_NAME_CITY expected")
+	}
+}
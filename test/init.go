@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
@@ -11,6 +12,7 @@ import (
 	go_ora "github.com/sijms/go-ora/v2"
 	oracle "github.com/uonun/gorm-oracle"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 var dsn string
@@ -52,6 +54,47 @@ func getDb(t *testing.T) *gorm.DB {
 	return db.Debug()
 }
 
+// getDryRunDb returns a db generating SQL only, without connecting to the database.
+func getDryRunDb(t *testing.T, serverVersion string) *gorm.DB {
+	return getDryRunDbWithConfig(t, oracle.Config{ServerVersion: serverVersion})
+}
+
+// getDryRunDbWithConfig returns a db generating SQL only by the specified configuration.
+func getDryRunDbWithConfig(t *testing.T, config oracle.Config) *gorm.DB {
+	config.DSN = dsn
+	config.SkipInitializeWithVersion = true
+	dialector := oracle.New(config)
+	db, err := gorm.Open(dialector, &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("gorm.Open Error %s", err)
+	}
+
+	return db
+}
+
+// getDryRunDbWithRecorder returns a db generating SQL only, the SQL of every statement
+// is recorded, e.g.: the DDL generated by the Migrator.
+func getDryRunDbWithRecorder(t *testing.T, serverVersion string) (*gorm.DB, *sqlRecorder) {
+	recorder := &sqlRecorder{Interface: logger.Discard}
+	db := getDryRunDb(t, serverVersion).Session(&gorm.Session{Logger: recorder})
+	return db, recorder
+}
+
+// sqlRecorder records the SQL traced by gorm
+type sqlRecorder struct {
+	logger.Interface
+	sqls []string
+}
+
+func (r *sqlRecorder) LogMode(logger.LogLevel) logger.Interface {
+	return r
+}
+
+func (r *sqlRecorder) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	sql, _ := fc()
+	r.sqls = append(r.sqls, sql)
+}
+
 func getCustomerWithSequenceButNotReturning(name string) CustomerWithSequenceButNotReturning {
 	return CustomerWithSequenceButNotReturning{
 		CustomerName: fmt.Sprintf("%s:%s", name, uuid.New().String()),
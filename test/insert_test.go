@@ -1,6 +1,7 @@
 package test
 
 import (
+	"database/sql/driver"
 	"fmt"
 	"os"
 	"strconv"
@@ -8,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -162,3 +165,127 @@ func TestInsertModelsWithAutoReturning(t *testing.T) {
 	}
 	t.Logf("created: %s", strings.Join(ids, ","))
 }
+
+func TestInsertModelsWithReturningSQL(t *testing.T) {
+	db := getDryRunDb(t, version11g)
+
+	cs := []Customer{getCustomer("TestInsertModelsWithReturningSQL"), getCustomer("TestInsertModelsWithReturningSQL")}
+	tx := checkTxError(t, db.Create(&cs))
+
+	sql := tx.Statement.SQL.String()
+	if strings.Contains(sql, "COMMIT") {
+		t.Errorf("batch insert must not commit the transaction of caller:\n%s", sql)
+	}
+	if !strings.Contains(sql, "RETURNING CUSTOMER_ID BULK COLLECT INTO o0;") {
+		t.Errorf("returning values are not collected:\n%s", sql)
+	}
+	// 7 columns for each row, the rows affected, and the CUSTOMER_ID of each row
+	if len(tx.Statement.Vars) != 7*2+1+2 {
+		t.Errorf("%d vars bound, %d expected:\n%s", len(tx.Statement.Vars), 7*2+1+2, sql)
+	}
+	t.Log(sql)
+}
+
+func TestTxInsertModelsWithReturning(t *testing.T) {
+	count := 4
+	batchId := uuid.NewString()
+	cs := make([]Customer, count)
+	for i := 0; i < count; i++ {
+		cs[i] = getCustomer(fmt.Sprintf("TestTxInsertModelsWithReturning:batch-%s:", batchId))
+	}
+
+	db := getDb(t).Begin()
+	tx := checkTxError(t, db.Create(&cs))
+	if tx.RowsAffected != int64(count) {
+		t.Errorf("batch insert %d rows affected, %d expected", tx.RowsAffected, count)
+	}
+	for i := 0; i < count; i++ {
+		if cs[i].CustomerID == 0 {
+			t.Errorf("not returning created value of row %d", i)
+		}
+	}
+
+	// rollback, nothing should be committed by the batch insert
+	checkTxError(t, db.Rollback())
+
+	var found int64
+	checkTxError(t, getDb(t).Model(&Customer{}).Where("CUSTOMER_NAME LIKE ?", fmt.Sprintf("%%%s%%", batchId)).Count(&found))
+	if found != 0 {
+		t.Errorf("%d rows committed after rollback, 0 expected", found)
+	}
+}
+
+func TestInsertModelsByArrayBindingSQL(t *testing.T) {
+	db := getDryRunDbWithConfig(t, oracle.Config{ServerVersion: version11g, BatchInsertWithArrayBinding: true})
+
+	cs := []CustomerWithSequenceButNotReturning{
+		getCustomerWithSequenceButNotReturning("TestInsertModelsByArrayBindingSQL"),
+		getCustomerWithSequenceButNotReturning("TestInsertModelsByArrayBindingSQL"),
+		getCustomerWithSequenceButNotReturning("TestInsertModelsByArrayBindingSQL"),
+	}
+	tx := checkTxError(t, db.Create(&cs))
+
+	expected := "INSERT INTO Customers (CUSTOMER_NAME,CUSTOMER_ID,ADDRESS,CITY,STATE,ZIP_CODE,CREATED_TIME,AGE) VALUES (:1,CUSTOMERS_S.NEXTVAL,:2,:3,:4,:5,:6,:7)"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+
+	// one slice of values for each column
+	if len(tx.Statement.Vars) != 7 {
+		t.Fatalf("%d vars bound, 7 expected", len(tx.Statement.Vars))
+	}
+	if col, ok := tx.Statement.Vars[0].([]driver.Value); !ok || len(col) != len(cs) || col[0] != cs[0].CustomerName {
+		t.Errorf("unexpected values of column CUSTOMER_NAME: %v", tx.Statement.Vars[0])
+	}
+}
+
+func TestInsertModelsByArrayBindingFallbackSQL(t *testing.T) {
+	newCustomers := func() []CustomerWithSequenceButNotReturning {
+		return []CustomerWithSequenceButNotReturning{
+			getCustomerWithSequenceButNotReturning("TestInsertModelsByArrayBindingFallbackSQL"),
+			getCustomerWithSequenceButNotReturning("TestInsertModelsByArrayBindingFallbackSQL"),
+		}
+	}
+	expected := checkTxError(t, getDryRunDb(t, version11g).Create(newCustomers())).Statement.SQL.String()
+
+	// the prepared statements of gorm are not executed by the wrapped connection of go-ora
+	dialector := oracle.New(oracle.Config{DSN: dsn, ServerVersion: version11g, SkipInitializeWithVersion: true, BatchInsertWithArrayBinding: true})
+	db, err := gorm.Open(dialector, &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true, PrepareStmt: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := checkTxError(t, db.Create(newCustomers()))
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+	for _, v := range tx.Statement.Vars {
+		if _, ok := v.([]driver.Value); ok {
+			t.Fatalf("values bound by array: %v", tx.Statement.Vars)
+		}
+	}
+}
+
+func TestInsertModelsByArrayBinding(t *testing.T) {
+	count := 1000
+	batchId := uuid.NewString()
+	cs := make([]Customer, count)
+	for i := 0; i < count; i++ {
+		cs[i] = getCustomer(fmt.Sprintf("TestInsertModelsByArrayBinding:batch-%s:", batchId))
+	}
+
+	db, err := gorm.Open(oracle.New(oracle.Config{DSN: dsn, BatchInsertWithArrayBinding: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open Error %s", err)
+	}
+
+	tx := checkTxError(t, db.CreateInBatches(&cs, 300))
+	if tx.RowsAffected != int64(count) {
+		t.Errorf("batch insert %d rows affected, %d expected", tx.RowsAffected, count)
+	}
+	for i := 0; i < count; i++ {
+		if cs[i].CustomerID == 0 {
+			t.Errorf("not returning created value of row %d", i)
+		}
+	}
+}
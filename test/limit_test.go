@@ -1,10 +1,96 @@
 package test
 
-// NOT SUPPORTED IN ORACLE 11
-// func TestQueryLimitModel(t *testing.T) {
-// 	db := getDb(t)
+import (
+	"strings"
+	"testing"
+)
 
-// 	var rows = []CustomerWithPrimaryKey{}
-// 	checkTxError(t, db.Limit(3).Find(&rows))
+const (
+	version11g = "Oracle Database 11g Enterprise Edition Release 11.2.0.4.0 - 64bit Production"
+	version19c = "Oracle Database 19c Enterprise Edition Release 19.0.0.0.0 - Production"
+)
 
-// }
+func TestQueryLimitModel(t *testing.T) {
+	db := getDb(t)
+
+	var rows = []CustomerWithPrimaryKey{}
+	tx := checkTxError(t, db.Limit(3).Find(&rows))
+	if tx.RowsAffected > 3 {
+		t.Errorf("%d rows returned, 3 expected at most", tx.RowsAffected)
+	}
+}
+
+func TestQueryLimitOffsetModel(t *testing.T) {
+	db := getDb(t)
+
+	var all = []CustomerWithPrimaryKey{}
+	checkTxError(t, db.Order("CUSTOMER_ID").Limit(4).Find(&all))
+	if len(all) < 4 {
+		t.Skipf("not enough rows to test offset: %d", len(all))
+	}
+
+	var rows = []CustomerWithPrimaryKey{}
+	checkTxError(t, db.Order("CUSTOMER_ID").Limit(2).Offset(2).Find(&rows))
+	if len(rows) != 2 || rows[0].CustomerID != all[2].CustomerID || rows[1].CustomerID != all[3].CustomerID {
+		t.Errorf("unexpected rows of offset 2: %v", rows)
+	}
+}
+
+func TestQueryFirstModel(t *testing.T) {
+	db := getDb(t)
+
+	var row CustomerWithPrimaryKey
+	checkTxError(t, db.First(&row))
+	if row.CustomerID == 0 {
+		t.Errorf("TestQueryFirstModel no row return")
+	}
+}
+
+func TestQueryLimitSQLByRownum(t *testing.T) {
+	db := getDryRunDb(t, version11g)
+
+	var rows = []CustomerWithPrimaryKey{}
+	stmt := db.Where("AGE > ?", 10).Order("CUSTOMER_ID").Limit(10).Offset(20).Find(&rows).Statement
+	sql := stmt.SQL.String()
+
+	expected := "SELECT * FROM (SELECT a.*, ROWNUM rn FROM (SELECT * FROM Customers WHERE AGE > :p1 ORDER BY CUSTOMER_ID ) a WHERE ROWNUM <= 30) WHERE rn > 20"
+	if sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
+
+func TestQueryOffsetSQLByRownum(t *testing.T) {
+	db := getDryRunDb(t, version11g)
+
+	var rows = []CustomerWithPrimaryKey{}
+	sql := db.Offset(5).Find(&rows).Statement.SQL.String()
+
+	expected := "SELECT * FROM (SELECT a.*, ROWNUM rn FROM (SELECT * FROM Customers ) a) WHERE rn > 5"
+	if sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
+
+func TestQueryFirstSQLByRownum(t *testing.T) {
+	db := getDryRunDb(t, version11g)
+
+	var row CustomerWithPrimaryKey
+	sql := db.First(&row).Statement.SQL.String()
+
+	if !strings.HasPrefix(sql, "SELECT * FROM (SELECT a.*, ROWNUM rn FROM (SELECT * FROM Customers ORDER BY") ||
+		!strings.HasSuffix(sql, ") a WHERE ROWNUM <= 1) WHERE rn > 0") {
+		t.Errorf("unexpected SQL: %s", sql)
+	}
+}
+
+func TestQueryLimitSQLByOffsetFetch(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	var rows = []CustomerWithPrimaryKey{}
+	sql := db.Order("CUSTOMER_ID").Limit(10).Offset(20).Find(&rows).Statement.SQL.String()
+
+	expected := "SELECT * FROM Customers ORDER BY CUSTOMER_ID OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"
+	if sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
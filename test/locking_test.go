@@ -0,0 +1,142 @@
+package test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestLockingSQL(t *testing.T) {
+	db := getDryRunDb(t, version11g)
+
+	var rows []CustomerWithPrimaryKey
+	tx := checkTxError(t, db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("AGE > ?", 10).Find(&rows))
+
+	expected := "SELECT * FROM Customers WHERE AGE > :p1 FOR UPDATE SKIP LOCKED"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
+
+func TestLockingOfWaitSQL(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	var rows []CustomerWithPrimaryKey
+	tx := checkTxError(t, db.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: clause.CurrentTable}, Options: "wait 5"}).
+		Find(&rows))
+
+	expected := "SELECT * FROM Customers FOR UPDATE OF Customers.CUSTOMER_ID WAIT 5"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
+
+func TestLockingWithLimitSQL(t *testing.T) {
+	for _, version := range []string{version11g, version19c} {
+		db := getDryRunDb(t, version)
+
+		var rows []CustomerWithPrimaryKey
+		tx := checkTxError(t, db.Clauses(clause.Locking{Strength: "UPDATE", Options: "NOWAIT"}).
+			Where("AGE > ?", 10).Order("CUSTOMER_ID").Limit(10).Offset(5).Find(&rows))
+
+		expected := "SELECT * FROM Customers WHERE Customers.ROWID IN (" +
+			"SELECT rid FROM (SELECT a.rid, ROWNUM rn FROM (SELECT Customers.ROWID rid FROM Customers WHERE AGE > :p1 ORDER BY CUSTOMER_ID ) a WHERE ROWNUM <= 15) WHERE rn > 5" +
+			") ORDER BY CUSTOMER_ID FOR UPDATE NOWAIT"
+		if sql := tx.Statement.SQL.String(); sql != expected {
+			t.Errorf("unexpected SQL of %s:\n%s\nexpected:\n%s", version, sql, expected)
+		}
+	}
+}
+
+func TestLockingUnsupportedSQL(t *testing.T) {
+	db := getDryRunDb(t, version11g)
+
+	var rows []CustomerWithPrimaryKey
+	if tx := db.Clauses(clause.Locking{Strength: "SHARE"}).Find(&rows); tx.Error == nil {
+		t.Errorf("error expected for FOR SHARE")
+	}
+}
+
+func TestLockingSkipLockedWithLimitSQL(t *testing.T) {
+	for _, version := range []string{version11g, version19c} {
+		db := getDryRunDb(t, version)
+
+		// the rows are limited by the scan
+		var rows []CustomerWithPrimaryKey
+		tx := checkTxError(t, db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("AGE > ?", 10).Order("CUSTOMER_ID").Limit(3).Find(&rows))
+
+		expected := "SELECT * FROM Customers WHERE AGE > :p1 ORDER BY CUSTOMER_ID FOR UPDATE SKIP LOCKED"
+		if sql := tx.Statement.SQL.String(); sql != expected {
+			t.Errorf("unexpected SQL of %s:\n%s\nexpected:\n%s", version, sql, expected)
+		}
+
+		// the rows of Rows() can not be limited
+		_, err := db.Model(&CustomerWithPrimaryKey{}).Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).Limit(3).Rows()
+		if !errors.Is(err, oracle.ErrSkipLockedWithLimit) {
+			t.Errorf("ErrSkipLockedWithLimit expected of %s, got: %v", version, err)
+		}
+	}
+}
+
+func TestLockingSkipLockedWithLimitScan(t *testing.T) {
+	sessionDriver.reset(nil)
+	sessionDriver.results = map[string]valueRows{
+		"Customers": {columns: []string{"CUSTOMER_ID"}, values: [][]driver.Value{{int64(1)}, {int64(2)}, {int64(3)}, {int64(4)}, {int64(5)}}},
+	}
+	db, err := gorm.Open(oracle.New(oracle.Config{
+		DriverName:                sessionDriverName,
+		DSN:                       "session",
+		ServerVersion:             version19c,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	var rows []CustomerWithPrimaryKey
+	if err = db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Order("CUSTOMER_ID").Limit(2).Offset(1).Find(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].CustomerID != 2 || rows[1].CustomerID != 3 {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+
+	var first CustomerWithPrimaryKey
+	if err = db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).First(&first).Error; err != nil {
+		t.Fatal(err)
+	}
+	if first.CustomerID != 1 {
+		t.Errorf("unexpected row: %+v", first)
+	}
+
+	expected := []string{
+		"SELECT * FROM Customers ORDER BY CUSTOMER_ID FOR UPDATE SKIP LOCKED",
+		"SELECT * FROM Customers ORDER BY Customers.CUSTOMER_ID FOR UPDATE SKIP LOCKED",
+	}
+	if sessions := sessionDriver.sessions(); len(sessions) != 1 || !reflect.DeepEqual(sessions[0], expected) {
+		t.Errorf("unexpected statements: %q, expected: %q", sessions, expected)
+	}
+}
+
+func TestLockingSkipLocked(t *testing.T) {
+	db := getDb(t).Begin()
+	defer db.Rollback()
+
+	// the first 3 unlocked rows are fetched
+	var customers []CustomerWithPrimaryKey
+	checkTxError(t, db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Order("CUSTOMER_ID").Limit(3).Find(&customers))
+	if len(customers) > 3 {
+		t.Errorf("%d rows fetched, at most 3 expected", len(customers))
+	}
+}
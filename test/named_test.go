@@ -0,0 +1,104 @@
+package test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestNamedBindsRawSQL(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	tx := checkTxError(t, db.Exec("UPDATE CUSTOMERS SET AGE = :age WHERE CUSTOMER_NAME = :name OR CITY = :name OR STATE = 'S:1'",
+		sql.Named("name", "x"), map[string]interface{}{"age": 18}))
+
+	expected := "UPDATE CUSTOMERS SET AGE = :p1 WHERE CUSTOMER_NAME = :p2 OR CITY = :p3 OR STATE = 'S:1'"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+	if vars := []interface{}{18, "x", "x"}; !reflect.DeepEqual(tx.Statement.Vars, vars) {
+		t.Errorf("unexpected vars: %v, expected: %v", tx.Statement.Vars, vars)
+	}
+}
+
+func TestNamedBindsWhereSQL(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	var rows []CustomerWithPrimaryKey
+	tx := checkTxError(t, db.Where("AGE > ?", 10).Where("CITY = :city", sql.Named("city", "c")).Find(&rows))
+
+	expected := "SELECT * FROM Customers WHERE AGE > :p1 AND CITY = :p2"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+	if vars := []interface{}{10, "c"}; !reflect.DeepEqual(tx.Statement.Vars, vars) {
+		t.Errorf("unexpected vars: %v, expected: %v", tx.Statement.Vars, vars)
+	}
+}
+
+func TestNamedBindsGormSQL(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	tx := checkTxError(t, db.Exec("DELETE FROM CUSTOMERS WHERE CITY = @city OR STATE = @city", sql.Named("city", "c")))
+
+	expected := "DELETE FROM CUSTOMERS WHERE CITY = :p1 OR STATE = :p2"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
+
+func TestNamedBindsMissingSQL(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	if tx := db.Exec("DELETE FROM CUSTOMERS WHERE CITY = :city OR STATE = :state", sql.Named("city", "c")); tx.Error == nil {
+		t.Errorf("error expected for missing named parameter")
+	}
+}
+
+func TestNamedBindsRaw(t *testing.T) {
+	db := getDb(t)
+
+	var count int
+	checkTxError(t, db.Raw("SELECT COUNT(*) FROM CUSTOMERS WHERE CUSTOMER_NAME = :name OR CITY = :name OR AGE > :age",
+		sql.Named("name", "x"), map[string]interface{}{"age": 18}).Scan(&count))
+}
+
+func TestNamedBindsUpdateSQL(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	tx := checkTxError(t, db.Model(&CustomerWithPrimaryKey{}).Where("CITY = :city OR STATE = :city", sql.Named("city", "c")).
+		Updates(map[string]interface{}{"AGE": gorm.Expr("AGE + :n", map[string]interface{}{"n": 1})}))
+
+	expected := "UPDATE Customers SET AGE=AGE + :p1 WHERE CITY = :p2 OR STATE = :p3"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+	if vars := []interface{}{1, "c", "c"}; !reflect.DeepEqual(tx.Statement.Vars, vars) {
+		t.Errorf("unexpected vars: %v, expected: %v", tx.Statement.Vars, vars)
+	}
+}
+
+func TestNamedBindsDeleteSQL(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	tx := checkTxError(t, db.Where("AGE > ? AND CITY = :city", 10, sql.Named("city", "c")).Delete(&CustomerWithPrimaryKey{}))
+
+	expected := "DELETE FROM Customers WHERE AGE > :p1 AND CITY = :p2"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+	if vars := []interface{}{10, "c"}; !reflect.DeepEqual(tx.Statement.Vars, vars) {
+		t.Errorf("unexpected vars: %v, expected: %v", tx.Statement.Vars, vars)
+	}
+}
+
+func TestNamedBindsUpdate(t *testing.T) {
+	db := getDb(t).Begin()
+	defer db.Rollback()
+
+	checkTxError(t, db.Model(&CustomerWithPrimaryKey{}).Where("CITY = :city", sql.Named("city", "x")).
+		Update("AGE", gorm.Expr("AGE + :n", sql.Named("n", 1))))
+	checkTxError(t, db.Where("CITY = :city", map[string]interface{}{"city": "x"}).Delete(&CustomerWithPrimaryKey{}))
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm/schema"
+)
+
+type CustomerOrder struct {
+	OrderID     int64 `gorm:"primaryKey"`
+	CustomerID  int64 `gorm:"index"`
+	TotalAmount float64
+	CreatedAt   time.Time
+}
+
+func TestNamingStrategySQL(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	var rows []CustomerOrder
+	tx := checkTxError(t, db.Where(&CustomerOrder{CustomerID: 1}).Order("CREATED_AT").Find(&rows))
+
+	expected := "SELECT * FROM CUSTOMER_ORDERS WHERE CUSTOMER_ORDERS.CUSTOMER_ID = :p1 ORDER BY CREATED_AT"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
+
+func TestNamingStrategyNotUsedSQL(t *testing.T) {
+	db := getDryRunDbWithConfig(t, oracle.Config{ServerVersion: version19c, DontUseNamingStrategy: true})
+
+	var rows []CustomerOrder
+	tx := checkTxError(t, db.Where(&CustomerOrder{CustomerID: 1}).Find(&rows))
+
+	expected := "SELECT * FROM customer_orders WHERE customer_orders.customer_id = :p1"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
+
+func TestNamingStrategyWithOwner(t *testing.T) {
+	ns := oracle.NamingStrategy{NamingStrategy: schema.NamingStrategy{TablePrefix: "t_"}, Owner: "app"}
+
+	for _, c := range []struct{ actual, expected string }{
+		{ns.TableName("CustomerOrder"), "APP.T_CUSTOMER_ORDERS"},
+		{ns.SchemaName("APP.T_CUSTOMER_ORDERS"), "CustomerOrder"},
+		{ns.ColumnName("", "TotalAmount"), "TOTAL_AMOUNT"},
+		{ns.JoinTableName("customer_products"), "APP.T_CUSTOMER_PRODUCTS"},
+		{ns.IndexName("APP.T_CUSTOMER_ORDERS", "CustomerID"), "IDX_T_CUSTOMER_ORDERS_CUSTOMER_ID"},
+		{ns.CheckerName("APP.T_CUSTOMER_ORDERS", "TOTAL_AMOUNT"), "CHK_T_CUSTOMER_ORDERS_TOTAL_AMOUNT"},
+	} {
+		if c.actual != c.expected {
+			t.Errorf("%q expected, but got %q", c.expected, c.actual)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+)
+
+type CustomerWithReservedWords struct {
+	ID      int64  `gorm:"column:ID;primaryKey"`
+	Level   int    `gorm:"column:LEVEL"`
+	Comment string `gorm:"column:comment"`
+	Size    int    `gorm:"column:SIZE"`
+}
+
+func (CustomerWithReservedWords) TableName() string {
+	return "Customers"
+}
+
+func TestQuoteReservedWordsSQL(t *testing.T) {
+	db := getDryRunDbWithConfig(t, oracle.Config{ServerVersion: version19c, QuoteMode: oracle.QuoteReservedWords})
+
+	var rows []CustomerWithReservedWords
+	tx := checkTxError(t, db.Select("ID", "LEVEL", "comment").Where(&CustomerWithReservedWords{Size: 1}).Find(&rows))
+
+	expected := `SELECT ID,"LEVEL","COMMENT" FROM Customers WHERE Customers."SIZE" = :p1`
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
+
+func TestQuoteAlwaysSQL(t *testing.T) {
+	db := getDryRunDbWithConfig(t, oracle.Config{ServerVersion: version19c, QuoteMode: oracle.QuoteAlways})
+
+	var rows []CustomerWithReservedWords
+	tx := checkTxError(t, db.Table(`APP."MixedCase"`).Where(&CustomerWithReservedWords{Size: 1}).Find(&rows))
+
+	expected := `SELECT * FROM "APP"."MixedCase" WHERE "MixedCase"."SIZE" = :p1`
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
+
+func TestQuoteNeverSQL(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	var rows []CustomerWithReservedWords
+	tx := checkTxError(t, db.Where(&CustomerWithReservedWords{Size: 1}).Find(&rows))
+
+	expected := `SELECT * FROM Customers WHERE Customers.SIZE = :p1`
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
+
+func TestQuoteBatchInsertReturningSQL(t *testing.T) {
+	db := getDryRunDbWithConfig(t, oracle.Config{ServerVersion: version19c, QuoteMode: oracle.QuoteAlways})
+
+	rows := []Customer{getCustomer("TestQuoteBatchInsertReturningSQL"), getCustomer("TestQuoteBatchInsertReturningSQL")}
+	tx := checkTxError(t, db.Create(&rows))
+
+	sql := tx.Statement.SQL.String()
+	for _, expected := range []string{
+		`TYPE t IS TABLE OF "CUSTOMERS"%ROWTYPE;`,
+		`TYPE t_o0 IS TABLE OF "CUSTOMERS"."CUSTOMER_ID"%TYPE;`,
+		`r(r.last)."CUSTOMER_NAME" := `,
+		`"CUSTOMERS_S".NEXTVAL`,
+		`RETURNING "CUSTOMER_ID" BULK COLLECT INTO o0;`,
+	} {
+		if !strings.Contains(sql, expected) {
+			t.Errorf("%q is expected in SQL:\n%s", expected, sql)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	go_ora "github.com/sijms/go-ora/v2"
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const returningDriverName = "oracle-returning-test"
+
+var returningDriver = &fakeReturningDriver{}
+
+func init() {
+	sql.Register(returningDriverName, returningDriver)
+}
+
+// fakeReturningDriver executes the RETURNING blocks without connecting to the database, the out
+// parameter :rows_affected is set to rowsAffected, and the out array parameters are filled with
+// rowsAffected rows if they are large enough, e.g.: CUSTOMER_ID is 1, 2, ... and the strings
+// are row1, row2, ...
+type fakeReturningDriver struct {
+	rowsAffected int64
+	queries      []string
+}
+
+func (d *fakeReturningDriver) Open(_ string) (driver.Conn, error) {
+	return &fakeReturningConn{driver: d}, nil
+}
+
+type fakeReturningConn struct {
+	driver *fakeReturningDriver
+}
+
+// CheckNamedValue accepts the out parameters as go-ora does
+func (c *fakeReturningConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+func (c *fakeReturningConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.driver.queries = append(c.driver.queries, query)
+	rows := int(c.driver.rowsAffected)
+	for _, arg := range args {
+		switch v := arg.Value.(type) {
+		case *int64:
+			// the first out parameter is :rows_affected
+			*v = c.driver.rowsAffected
+		case go_ora.Out:
+			// the statement is rolled back if the rows exceed the out parameters
+			if v.Size < rows {
+				continue
+			}
+			for r := 1; r <= rows; r++ {
+				switch dest := v.Dest.(type) {
+				case *[]sql.NullInt64:
+					*dest = append(*dest, sql.NullInt64{Int64: int64(r), Valid: true})
+				case *[]sql.NullString:
+					*dest = append(*dest, sql.NullString{String: fmt.Sprintf("row%d", r), Valid: true})
+				case *[]go_ora.NullTimeStamp:
+					*dest = append(*dest, go_ora.NullTimeStamp{})
+				}
+			}
+		}
+	}
+	return emptyRows{}, nil
+}
+
+func (c *fakeReturningConn) Prepare(_ string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeReturningConn) Close() error {
+	return nil
+}
+
+func (c *fakeReturningConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string {
+	return nil
+}
+
+func (emptyRows) Close() error {
+	return nil
+}
+
+func (emptyRows) Next([]driver.Value) error {
+	return io.EOF
+}
+
+func TestUpdateReturningSQL(t *testing.T) {
+	db := getDryRunDb(t, version11g)
+
+	rows := []CustomerWithPrimaryKey{{CustomerID: 1}, {CustomerID: 2}}
+	tx := checkTxError(t, db.Clauses(clause.Returning{Columns: []clause.Column{{Name: "AGE"}}}).
+		Model(&rows).Update("AGE", 18))
+
+	sql := tx.Statement.SQL.String()
+	for _, part := range []string{
+		"DECLARE\n",
+		"TYPE t_o0 IS TABLE OF Customers.AGE%TYPE;",
+		"TYPE t_o1 IS TABLE OF Customers.CUSTOMER_ID%TYPE;",
+		"BEGIN\n\tSAVEPOINT GORM_ORACLE_RETURNING;\n\tUPDATE Customers SET AGE=:p1 WHERE (CUSTOMER_ID = :p2 OR CUSTOMER_ID = :p3) RETURNING AGE,CUSTOMER_ID BULK COLLECT INTO o0,o1;",
+		":rows_affected := SQL%ROWCOUNT;",
+		"IF o0.COUNT > :capacity THEN\n\t\tROLLBACK TO GORM_ORACLE_RETURNING;",
+		":o1(i) := o1(i);",
+	} {
+		if !strings.Contains(sql, part) {
+			t.Errorf("%q is expected in SQL:\n%s", part, sql)
+		}
+	}
+
+	// 3 parameters, the rows affected, the capacity, and the arrays of 2 columns
+	if len(tx.Statement.Vars) != 3+1+1+2 {
+		t.Errorf("%d vars bound, %d expected", len(tx.Statement.Vars), 3+1+1+2)
+	}
+	if out, ok := tx.Statement.Vars[6].(go_ora.Out); !ok || out.Size != 2 {
+		t.Errorf("unexpected out parameter: %#v", tx.Statement.Vars[6])
+	}
+}
+
+func TestDeleteReturningSQL(t *testing.T) {
+	db := getDryRunDb(t, version11g)
+
+	rows := []CustomerWithPrimaryKey{{CustomerID: 1}, {CustomerID: 2}}
+	tx := checkTxError(t, db.Clauses(clause.Returning{}).Delete(&rows))
+
+	sql := tx.Statement.SQL.String()
+	if !strings.Contains(sql, "DELETE FROM Customers WHERE Customers.CUSTOMER_ID IN (:p1,:p2) RETURNING CUSTOMER_NAME,ADDRESS,CITY,STATE,ZIP_CODE,CREATED_TIME,AGE,CUSTOMER_ID BULK COLLECT INTO o0,o1,o2,o3,o4,o5,o6,o7;") {
+		t.Errorf("unexpected SQL:\n%s", sql)
+	}
+}
+
+func TestDeleteReturning(t *testing.T) {
+	db := getDb(t)
+
+	rows := []CustomerWithPrimaryKey{getCustomerWithPrimaryKey("TestDeleteReturning"), getCustomerWithPrimaryKey("TestDeleteReturning")}
+	checkTxError(t, db.Create(&rows))
+
+	names := []string{rows[0].CustomerName, rows[1].CustomerName}
+	rows[0].CustomerName, rows[1].CustomerName = "", ""
+
+	tx := checkTxError(t, db.Clauses(clause.Returning{}).Delete(&rows))
+	if tx.RowsAffected != 2 {
+		t.Errorf("%d rows affected, 2 expected", tx.RowsAffected)
+	}
+	for i := range rows {
+		if rows[i].CustomerName != names[i] {
+			t.Errorf("unexpected returning value of row %d: %s, %s expected", i, rows[i].CustomerName, names[i])
+		}
+	}
+}
+
+func TestDeleteReturningRows(t *testing.T) {
+	*returningDriver = fakeReturningDriver{rowsAffected: 3}
+	db, err := gorm.Open(oracle.New(oracle.Config{
+		DriverName:                returningDriverName,
+		DSN:                       "returning",
+		ServerVersion:             version11g,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{SkipDefaultTransaction: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the statement is executed again with the out parameters enlarged, and the rows are appended
+	var rows []CustomerWithPrimaryKey
+	tx := db.Clauses(clause.Returning{}).Where("AGE > ?", 18).Delete(&rows)
+	if tx.Error != nil {
+		t.Fatal(tx.Error)
+	}
+	if len(returningDriver.queries) != 2 {
+		t.Errorf("unexpected statements: %q", returningDriver.queries)
+	}
+	if tx.RowsAffected != 3 || len(rows) != 3 {
+		t.Fatalf("3 rows expected, %d rows affected, got: %v", tx.RowsAffected, rows)
+	}
+	for i, row := range rows {
+		if name := fmt.Sprintf("row%d", i+1); row.CustomerID != int64(i+1) || row.CustomerName != name || string(row.Address) != name || row.Age != int32(i+1) {
+			t.Errorf("unexpected row %d: %+v", i, row)
+		}
+	}
+
+	// the rows are matched by primary keys, the element without primary key is filled by
+	// the unmatched row, and the rest are appended
+	returningDriver.queries = nil
+	pointers := []*CustomerWithPrimaryKey{{CustomerID: 2}, {}}
+	if err = db.Clauses(clause.Returning{}).Where("AGE > ?", 18).Delete(&pointers).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(returningDriver.queries) != 2 {
+		t.Errorf("unexpected statements: %q", returningDriver.queries)
+	}
+	if len(pointers) != 3 {
+		t.Fatalf("3 rows expected, got: %v", pointers)
+	}
+	for i, id := range []int64{2, 1, 3} {
+		if pointers[i].CustomerID != id || pointers[i].CustomerName != fmt.Sprintf("row%d", id) {
+			t.Errorf("unexpected row %d: %+v", i, pointers[i])
+		}
+	}
+
+	// the struct is filled by the first row
+	returningDriver.queries = nil
+	var customer CustomerWithPrimaryKey
+	if err = db.Clauses(clause.Returning{}).Model(&customer).Where("AGE > ?", 18).Update("AGE", 20).Error; err != nil {
+		t.Fatal(err)
+	}
+	if customer.CustomerID != 1 || customer.CustomerName != "row1" {
+		t.Errorf("unexpected row: %+v", customer)
+	}
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+)
+
+func TestWithSchemaCurrentDatabase(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	for schema, expected := range map[string]string{
+		"tenant_42":  "TENANT_42",
+		`"Tenant42"`: "Tenant42",
+	} {
+		if name := db.Scopes(oracle.WithSchema(schema)).Migrator().CurrentDatabase(); name != expected {
+			t.Errorf("unexpected schema of %s: %s, expected: %s", schema, name, expected)
+		}
+	}
+}
+
+func TestWithSchemaInvalid(t *testing.T) {
+	db := getDryRunDb(t, version19c)
+
+	for _, schema := range []string{"", "tenant; DROP TABLE users", `"a"b"`} {
+		var rows []CustomerOrder
+		if err := db.Scopes(oracle.WithSchema(schema)).Find(&rows).Error; err == nil {
+			t.Errorf("error expected for the invalid schema %q", schema)
+		}
+	}
+}
+
+func TestWithSchemaUnwrapped(t *testing.T) {
+	fakeDb, _ := getFakeDb(t)
+
+	for name, db := range map[string]*gorm.DB{
+		"Config.Conn": fakeDb,
+		"PrepareStmt": getDryRunDb(t, version19c).Session(&gorm.Session{PrepareStmt: true}),
+	} {
+		var rows []CustomerOrder
+		if err := db.Scopes(oracle.WithSchema("TENANT_42")).Find(&rows).Error; !errors.Is(err, oracle.ErrSchemaNotSwitched) {
+			t.Errorf("ErrSchemaNotSwitched expected with %s, got: %v", name, err)
+		}
+	}
+}
+
+func TestWithSchema(t *testing.T) {
+	db := getDb(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the schema is switched and restored on the same connection
+	sqlDB.SetMaxOpenConns(1)
+
+	var original string
+	if err = db.Raw("SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL").Scan(&original).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tenant := db.Scopes(oracle.WithSchema("SYSTEM"))
+	var current string
+	if err = tenant.Raw("SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL").Scan(&current).Error; err != nil {
+		t.Fatal(err)
+	}
+	if current != "SYSTEM" {
+		t.Errorf("unexpected schema: %s, expected: SYSTEM", current)
+	}
+	if !db.Scopes(oracle.WithSchema("SYSTEM")).Migrator().HasTable("HELP") {
+		t.Errorf("SYSTEM.HELP expected")
+	}
+
+	if err = db.Raw("SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL").Scan(&current).Error; err != nil {
+		t.Fatal(err)
+	}
+	if current != original {
+		t.Errorf("schema is not restored: %s, expected: %s", current, original)
+	}
+}
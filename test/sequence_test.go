@@ -0,0 +1,162 @@
+package test
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+)
+
+type SequencedCustomer struct {
+	CustomerID int64  `gorm:"column:CUSTOMER_ID;primaryKey;sequence:SEQUENCED_CUSTOMERS_S"`
+	Name       string `gorm:"column:NAME;size:100"`
+}
+
+func TestSequenceSQL(t *testing.T) {
+	for version, expected := range map[string][]string{
+		version19c: {
+			"CREATE SEQUENCE APP.SEQUENCED_CUSTOMERS_S START WITH 100 INCREMENT BY 2 CACHE 50 ORDER",
+			"ALTER SEQUENCE APP.SEQUENCED_CUSTOMERS_S NOCACHE",
+			"ALTER SEQUENCE APP.SEQUENCED_CUSTOMERS_S RESTART START WITH 1000",
+			"DROP SEQUENCE APP.SEQUENCED_CUSTOMERS_S",
+		},
+		// the sequence is dropped and created again before 18c
+		version11g: {
+			"CREATE SEQUENCE APP.SEQUENCED_CUSTOMERS_S START WITH 100 INCREMENT BY 2 CACHE 50 ORDER",
+			"ALTER SEQUENCE APP.SEQUENCED_CUSTOMERS_S NOCACHE",
+			"DROP SEQUENCE APP.SEQUENCED_CUSTOMERS_S",
+			"CREATE SEQUENCE APP.SEQUENCED_CUSTOMERS_S START WITH 1000",
+			"DROP SEQUENCE APP.SEQUENCED_CUSTOMERS_S",
+		},
+	} {
+		db, recorder := getDryRunDbWithRecorder(t, version)
+		migrator := db.Migrator().(oracle.Migrator)
+
+		const name = "APP.SEQUENCED_CUSTOMERS_S"
+		if err := migrator.CreateSequence(&SequencedCustomer{}, name, oracle.SequenceOptions{StartWith: 100, IncrementBy: 2, Cache: 50, Order: true}); err != nil {
+			t.Fatal(err)
+		}
+		if err := migrator.AlterSequence(&SequencedCustomer{}, name, oracle.SequenceOptions{Cache: -1}); err != nil {
+			t.Fatal(err)
+		}
+		if err := migrator.ResetSequence(&SequencedCustomer{}, name, oracle.SequenceOptions{StartWith: 1000}); err != nil {
+			t.Fatal(err)
+		}
+		if err := migrator.DropSequence(&SequencedCustomer{}, name); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(recorder.sqls, expected) {
+			t.Errorf("unexpected SQL of %s: %q, expected: %q", version, recorder.sqls, expected)
+		}
+	}
+}
+
+func TestResetSequenceStartWithMaxOf(t *testing.T) {
+	sessionDriver.reset(nil)
+	sessionDriver.results = map[string]valueRows{
+		"MAX(": {columns: []string{"MAX"}, values: [][]driver.Value{{int64(41)}}},
+	}
+	db, err := gorm.Open(oracle.New(oracle.Config{
+		DriverName:                sessionDriverName,
+		DSN:                       "session",
+		ServerVersion:             version11g,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	// the MAX value is read before the sequence is dropped
+	const name = "SEQUENCED_CUSTOMERS_S"
+	if err = db.Migrator().(oracle.Migrator).ResetSequence(&SequencedCustomer{}, name, oracle.SequenceOptions{StartWithMaxOf: "CustomerID"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"SELECT COALESCE(MAX(CUSTOMER_ID), 0) FROM SEQUENCED_CUSTOMERS",
+		"DROP SEQUENCE SEQUENCED_CUSTOMERS_S",
+		"CREATE SEQUENCE SEQUENCED_CUSTOMERS_S START WITH 42",
+	}
+	if sessions := sessionDriver.sessions(); len(sessions) != 1 || !reflect.DeepEqual(sessions[0], expected) {
+		t.Errorf("unexpected statements: %q, expected: %q", sessions, expected)
+	}
+}
+
+func TestSequenceCreateTableSQL(t *testing.T) {
+	db, recorder := getDryRunDbWithRecorder(t, version19c)
+
+	// the values of the primary key are generated by the sequence instead of the IDENTITY column
+	if err := db.Migrator().CreateTable(&SequencedCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.sqls) != 1 {
+		t.Fatalf("unexpected SQL: %q", recorder.sqls)
+	}
+	if !strings.Contains(recorder.sqls[0], "CUSTOMER_ID NUMBER") || strings.Contains(recorder.sqls[0], "IDENTITY") {
+		t.Errorf("unexpected SQL: %s", recorder.sqls[0])
+	}
+}
+
+func TestSequence(t *testing.T) {
+	db := getDb(t)
+
+	migrator := db.Migrator().(oracle.Migrator)
+	if migrator.HasTable(&SequencedCustomer{}) {
+		if err := migrator.DropTable(&SequencedCustomer{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := migrator.CreateTable(&SequencedCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+	defer migrator.DropTable(&SequencedCustomer{})
+
+	const name = "SEQUENCED_CUSTOMERS_S"
+	if migrator.HasSequence(&SequencedCustomer{}, name) {
+		if err := migrator.DropSequence(&SequencedCustomer{}, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer migrator.DropSequence(&SequencedCustomer{}, name)
+
+	if err := db.Exec("INSERT INTO SEQUENCED_CUSTOMERS (CUSTOMER_ID, NAME) VALUES (41, 'alice')").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// the missing sequence is created by AutoMigrate, starting after the existing values
+	if err := db.AutoMigrate(&SequencedCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+	if !migrator.HasSequence(&SequencedCustomer{}, name) {
+		t.Fatalf("sequence %s expected", name)
+	}
+
+	customer := SequencedCustomer{Name: "bob"}
+	if err := db.Create(&customer).Error; err != nil {
+		t.Fatal(err)
+	}
+	var id int64
+	if err := db.Raw("SELECT MAX(CUSTOMER_ID) FROM SEQUENCED_CUSTOMERS").Row().Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+	if id != 42 {
+		t.Errorf("unexpected CUSTOMER_ID: %d, expected: 42", id)
+	}
+
+	if err := migrator.ResetSequence(&SequencedCustomer{}, name, oracle.SequenceOptions{StartWith: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	var next int64
+	if err := db.Raw("SELECT " + name + ".NEXTVAL FROM DUAL").Row().Scan(&next); err != nil {
+		t.Fatal(err)
+	}
+	if next != 1000 {
+		t.Errorf("unexpected NEXTVAL: %d, expected: 1000", next)
+	}
+}
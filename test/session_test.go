@@ -0,0 +1,305 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+)
+
+func TestSessionStatements(t *testing.T) {
+	config := oracle.Config{
+		NLSDateFormat:        "YYYY-MM-DD HH24:MI:SS",
+		NLSNumericCharacters: ".,",
+		NLSSort:              "BINARY_CI",
+		NLSComp:              "LINGUISTIC",
+		TimeZone:             "local",
+		CurrentSchema:        "app",
+		SessionStatements:    []string{"ALTER SESSION SET DDL_LOCK_TIMEOUT = 10", " "},
+	}
+	statements, err := config.InitStatements()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"ALTER SESSION SET NLS_DATE_FORMAT = 'YYYY-MM-DD HH24:MI:SS' NLS_NUMERIC_CHARACTERS = '.,' NLS_SORT = 'BINARY_CI' NLS_COMP = 'LINGUISTIC' TIME_ZONE = LOCAL CURRENT_SCHEMA = APP",
+		"ALTER SESSION SET DDL_LOCK_TIMEOUT = 10",
+	}
+	if !reflect.DeepEqual(statements, expected) {
+		t.Errorf("unexpected statements: %q, expected: %q", statements, expected)
+	}
+
+	config = oracle.Config{TimeZone: "Asia/Shanghai", NLSTimestampFormat: "YYYY-MM-DD\"T\"HH24:MI:SS's'", CurrentSchema: `"App"`}
+	statements, err = config.InitStatements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{`ALTER SESSION SET NLS_TIMESTAMP_FORMAT = 'YYYY-MM-DD"T"HH24:MI:SS''s''' TIME_ZONE = 'Asia/Shanghai' CURRENT_SCHEMA = "App"`}
+	if !reflect.DeepEqual(statements, expected) {
+		t.Errorf("unexpected statements: %q, expected: %q", statements, expected)
+	}
+
+	for _, schema := range []string{"app; DROP TABLE users", `"a"b"`} {
+		config = oracle.Config{CurrentSchema: schema}
+		if _, err = config.InitStatements(); err == nil {
+			t.Errorf("error expected for the invalid schema %q", schema)
+		}
+	}
+}
+
+func TestSessionStatementsOnEveryConnection(t *testing.T) {
+	sessionDriver.reset(map[string]string{"NLS_DATE_FORMAT": "YYYY-MM-DD"})
+	db, err := gorm.Open(oracle.New(oracle.Config{
+		DriverName:                sessionDriverName,
+		DSN:                       "session",
+		SkipInitializeWithVersion: true,
+		NLSDateFormat:             "YYYY-MM-DD",
+		SessionStatements:         []string{"ALTER SESSION SET DDL_LOCK_TIMEOUT = 10"},
+	}), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	// holds two connections at the same time, so that both of them are new connections
+	ctx := context.Background()
+	conn1, err := sqlDB.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn1.Close()
+	conn2, err := sqlDB.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn2.Close()
+
+	expected := []string{"ALTER SESSION SET NLS_DATE_FORMAT = 'YYYY-MM-DD'", "ALTER SESSION SET DDL_LOCK_TIMEOUT = 10"}
+	sessions := sessionDriver.sessions()
+	if len(sessions) != 2 {
+		t.Fatalf("2 connections expected, got %d", len(sessions))
+	}
+	for idx, statements := range sessions {
+		if !reflect.DeepEqual(statements, expected) {
+			t.Errorf("unexpected statements of connection #%d: %q, expected: %q", idx+1, statements, expected)
+		}
+	}
+}
+
+func TestSessionStatementsFailed(t *testing.T) {
+	sessionDriver.reset(nil)
+	db, err := gorm.Open(oracle.New(oracle.Config{
+		DriverName:                sessionDriverName,
+		DSN:                       "session",
+		SkipInitializeWithVersion: true,
+		SessionStatements:         []string{"ALTER SESSION SET " + sessionFailure},
+	}), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	if err = sqlDB.Ping(); err == nil {
+		t.Fatalf("error expected when the session statements failed")
+	}
+	if _, ok := oracle.AsError(err); !ok {
+		t.Errorf("ORA- error expected, got: %v", err)
+	}
+}
+
+func TestSessionVerification(t *testing.T) {
+	sessionDriver.reset(map[string]string{"NLS_DATE_FORMAT": "DD-MON-RR", "TIME_ZONE": "+08:00", "CURRENT_SCHEMA": "APP"})
+	open := func(config oracle.Config) error {
+		config.DriverName, config.DSN = sessionDriverName, "session"
+		// verified without detecting the version
+		config.SkipInitializeWithVersion = true
+		db, err := gorm.Open(oracle.New(config), &gorm.Config{DisableAutomaticPing: true})
+		if err == nil {
+			sqlDB, _ := db.DB()
+			sqlDB.Close()
+		}
+		return err
+	}
+
+	// the offset is read back as +08:00
+	if err := open(oracle.Config{TimeZone: "+8:00", CurrentSchema: "app"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := open(oracle.Config{NLSDateFormat: "YYYY-MM-DD"}); err == nil || !strings.Contains(err.Error(), "NLS_DATE_FORMAT") {
+		t.Errorf("NLS_DATE_FORMAT is not reported: %v", err)
+	}
+	if err := open(oracle.Config{NLSDateFormat: "YYYY-MM-DD", SkipSessionVerification: true}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSessionSettingsWithConn(t *testing.T) {
+	_, err := gorm.Open(oracle.New(oracle.Config{
+		Conn:                      &fakeConnPool{},
+		SkipInitializeWithVersion: true,
+		TimeZone:                  "+08:00",
+	}), &gorm.Config{DisableAutomaticPing: true})
+	if err == nil {
+		t.Fatalf("error expected for the session settings with Config.Conn")
+	}
+}
+
+const (
+	sessionDriverName = "oracle-session-test"
+	sessionFailure    = "FAILURE"
+)
+
+var sessionDriver = &recordingDriver{}
+
+func init() {
+	sql.Register(sessionDriverName, sessionDriver)
+}
+
+// recordingDriver records the statements executed on each connection
+type recordingDriver struct {
+	mu    sync.Mutex
+	conns []*recordingConn
+	// parameters are read back by the verification of the session, TIME_ZONE and CURRENT_SCHEMA
+	// are read from DUAL, the others from NLS_SESSION_PARAMETERS
+	parameters map[string]string
+	// results are the rows returned by the queries containing the keys, e.g.: the table names
+	results map[string]valueRows
+}
+
+func (d *recordingDriver) Open(_ string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c := &recordingConn{driver: d}
+	d.conns = append(d.conns, c)
+	return c, nil
+}
+
+func (d *recordingDriver) reset(parameters map[string]string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.conns = nil
+	d.parameters = parameters
+	d.results = nil
+}
+
+func (d *recordingDriver) sessions() [][]string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	sessions := make([][]string, len(d.conns))
+	for idx, c := range d.conns {
+		sessions[idx] = c.statements
+	}
+	return sessions
+}
+
+type recordingConn struct {
+	driver     *recordingDriver
+	statements []string
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	if query == "ALTER SESSION SET "+sessionFailure {
+		return nil, errors.New("ORA-02248: invalid option for ALTER SESSION")
+	}
+	c.statements = append(c.statements, query)
+	return driver.RowsAffected(0), nil
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	parameters := c.driver.parameters
+	switch {
+	case strings.Contains(query, "NLS_SESSION_PARAMETERS"):
+		rows := &valueRows{columns: []string{"PARAMETER", "VALUE"}}
+		for name, value := range parameters {
+			if name != "TIME_ZONE" && name != "CURRENT_SCHEMA" {
+				rows.values = append(rows.values, []driver.Value{name, value})
+			}
+		}
+		return rows, nil
+	case strings.Contains(query, "SESSIONTIMEZONE"):
+		return &valueRows{
+			columns: []string{"SESSIONTIMEZONE", "CURRENT_SCHEMA"},
+			values:  [][]driver.Value{{parameters["TIME_ZONE"], parameters["CURRENT_SCHEMA"]}},
+		}, nil
+	}
+
+	c.statements = append(c.statements, query)
+	for table, rows := range c.driver.results {
+		if strings.Contains(query, table) {
+			rows := rows
+			return &rows, nil
+		}
+	}
+	return &valueRows{}, nil
+}
+
+func (c *recordingConn) Prepare(_ string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return recordingTx{c}, nil
+}
+
+// recordingTx records COMMIT and ROLLBACK as the statements
+type recordingTx struct {
+	*recordingConn
+}
+
+func (tx recordingTx) Commit() error {
+	_, err := tx.ExecContext(context.Background(), "COMMIT", nil)
+	return err
+}
+
+func (tx recordingTx) Rollback() error {
+	_, err := tx.ExecContext(context.Background(), "ROLLBACK", nil)
+	return err
+}
+
+// valueRows returns the values as the rows of the result set
+type valueRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *valueRows) Columns() []string {
+	return r.columns
+}
+
+func (r *valueRows) Close() error {
+	return nil
+}
+
+func (r *valueRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
@@ -0,0 +1,362 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sijms/go-ora/v2/network"
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeConnPool begins fake transactions without connecting to the database
+type fakeConnPool struct {
+	begins, commits, rollbacks int
+	// execs are the statements executed
+	execs []string
+	// commitErr is returned by Commit
+	commitErr error
+}
+
+func (p *fakeConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (p *fakeConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	p.execs = append(p.execs, query)
+	return driver.RowsAffected(0), nil
+}
+
+func (p *fakeConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+func (p *fakeConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func (p *fakeConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	p.begins++
+	return &fakeTx{fakeConnPool: p}, nil
+}
+
+type fakeTx struct {
+	*fakeConnPool
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.commits++
+	return tx.commitErr
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.rollbacks++
+	return nil
+}
+
+func getFakeDb(t *testing.T) (*gorm.DB, *fakeConnPool) {
+	pool := &fakeConnPool{}
+	db, err := gorm.Open(oracle.New(oracle.Config{Conn: pool, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open Error %s", err)
+	}
+	return db, pool
+}
+
+var errDeadlock = &network.OracleError{ErrCode: 60, ErrMsg: "ORA-00060: deadlock detected while waiting for resource"}
+
+func TestTransactionRetry(t *testing.T) {
+	db, pool := getFakeDb(t)
+
+	var attempts int
+	err := oracle.Transaction(db, &oracle.TransactionOptions{Backoff: func(int) time.Duration { return 0 }}, func(tx *gorm.DB) error {
+		if attempts++; attempts < 3 {
+			return errDeadlock
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if attempts != 3 || pool.begins != 3 || pool.rollbacks != 2 || pool.commits != 1 {
+		t.Errorf("unexpected attempts: %d, begins: %d, rollbacks: %d, commits: %d", attempts, pool.begins, pool.rollbacks, pool.commits)
+	}
+}
+
+func TestTransactionRetryExhausted(t *testing.T) {
+	db, _ := getFakeDb(t)
+
+	var attempts int
+	err := oracle.Transaction(db, &oracle.TransactionOptions{MaxAttempts: 2, Backoff: func(int) time.Duration { return 0 }}, func(tx *gorm.DB) error {
+		attempts++
+		return errDeadlock
+	})
+
+	var txErr *oracle.TransactionError
+	if !errors.As(err, &txErr) || len(txErr.Attempts) != 2 || attempts != 2 {
+		t.Fatalf("TransactionError of 2 attempts expected, but got %v", err)
+	}
+	if e, ok := oracle.AsError(err); !ok || e.Code != 60 {
+		t.Errorf("the last error is expected to be ORA-00060, but got %v", err)
+	}
+}
+
+func TestTransactionNotRetryable(t *testing.T) {
+	db, _ := getFakeDb(t)
+
+	var (
+		attempts int
+		errFn    = errors.New("not retryable")
+	)
+	err := oracle.Transaction(db, nil, func(tx *gorm.DB) error {
+		attempts++
+		return errFn
+	})
+
+	if err != errFn || attempts != 1 {
+		t.Errorf("unexpected error: %v, attempts: %d", err, attempts)
+	}
+}
+
+func TestTransactionCommitError(t *testing.T) {
+	db, pool := getFakeDb(t)
+	// the violation of the deferred constraint is raised by Commit
+	pool.commitErr = &network.OracleError{ErrCode: 1, ErrMsg: "ORA-00001: unique constraint (APP.PK_CUSTOMERS) violated"}
+
+	err := oracle.Transaction(db, nil, func(tx *gorm.DB) error {
+		return nil
+	})
+	if !errors.Is(err, oracle.ErrDuplicatedKey) {
+		t.Errorf("ErrDuplicatedKey expected, got: %v", err)
+	}
+}
+
+func TestTransactionCommitNotRetried(t *testing.T) {
+	db, pool := getFakeDb(t)
+	// the transaction may have been committed before the connection is lost
+	pool.commitErr = &network.OracleError{ErrCode: 3113, ErrMsg: "ORA-03113: end-of-file on communication channel"}
+
+	var attempts int
+	err := oracle.Transaction(db, &oracle.TransactionOptions{Backoff: func(int) time.Duration { return 0 }}, func(tx *gorm.DB) error {
+		attempts++
+		return nil
+	})
+	if e, ok := oracle.AsError(err); !ok || e.Code != 3113 || attempts != 1 || pool.commits != 1 {
+		t.Errorf("unexpected error: %v, attempts: %d, commits: %d", err, attempts, pool.commits)
+	}
+}
+
+func TestTransactionCanceled(t *testing.T) {
+	db, _ := getFakeDb(t)
+
+	// canceled by the attempt
+	ctx, cancel := context.WithCancel(context.Background())
+	var attempts int
+	err := oracle.Transaction(db.WithContext(ctx), &oracle.TransactionOptions{Backoff: func(int) time.Duration { return 0 }}, func(tx *gorm.DB) error {
+		attempts++
+		cancel()
+		return errDeadlock
+	})
+	if e, ok := oracle.AsError(err); !ok || e.Code != 60 || attempts != 1 {
+		t.Errorf("unexpected error: %v, attempts: %d", err, attempts)
+	}
+
+	// canceled while waiting for the next attempt
+	ctx, cancel = context.WithCancel(context.Background())
+	attempts = 0
+	err = oracle.Transaction(db.WithContext(ctx), &oracle.TransactionOptions{Backoff: func(int) time.Duration {
+		cancel()
+		return time.Minute
+	}}, func(tx *gorm.DB) error {
+		attempts++
+		return errDeadlock
+	})
+
+	var txErr *oracle.TransactionError
+	if !errors.As(err, &txErr) || len(txErr.Attempts) != 1 || attempts != 1 {
+		t.Fatalf("TransactionError of 1 attempt expected, but got %v", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("context.Canceled expected, got: %v", err)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := oracle.ExponentialBackoff(100*time.Millisecond, time.Second)
+	for attempt, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond, 5: time.Second, 10: time.Second} {
+		if d := backoff(attempt); d != expected {
+			t.Errorf("backoff of attempt %d: %v, expected: %v", attempt, d, expected)
+		}
+	}
+}
+
+func TestTransactionStatement(t *testing.T) {
+	ctx := context.Background()
+	for _, c := range []struct {
+		opts     *sql.TxOptions
+		ctx      context.Context
+		expected string
+	}{
+		{nil, ctx, ""},
+		{&sql.TxOptions{}, ctx, ""},
+		{&sql.TxOptions{Isolation: sql.LevelReadCommitted}, ctx, "SET TRANSACTION ISOLATION LEVEL READ COMMITTED"},
+		{&sql.TxOptions{Isolation: sql.LevelSerializable}, ctx, "SET TRANSACTION ISOLATION LEVEL SERIALIZABLE"},
+		{&sql.TxOptions{ReadOnly: true}, ctx, "SET TRANSACTION READ ONLY"},
+		{&sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true}, ctx, "SET TRANSACTION READ ONLY"},
+		{nil, oracle.WithTransactionName(ctx, "close_invoice"), "SET TRANSACTION NAME 'close_invoice'"},
+		{&sql.TxOptions{Isolation: sql.LevelSerializable}, oracle.WithTransactionName(ctx, "bob's"), "SET TRANSACTION ISOLATION LEVEL SERIALIZABLE NAME 'bob''s'"},
+	} {
+		stmt, err := oracle.TransactionStatement(c.ctx, c.opts)
+		if err != nil {
+			t.Errorf("unexpected error of %+v: %v", c.opts, err)
+		} else if stmt != c.expected {
+			t.Errorf("unexpected statement of %+v: %s, expected: %s", c.opts, stmt, c.expected)
+		}
+	}
+
+	for _, opts := range []*sql.TxOptions{
+		{Isolation: sql.LevelReadUncommitted},
+		{Isolation: sql.LevelRepeatableRead},
+		{Isolation: sql.LevelSnapshot},
+		{Isolation: sql.LevelLinearizable},
+		{Isolation: sql.LevelReadCommitted, ReadOnly: true},
+	} {
+		if _, err := oracle.TransactionStatement(ctx, opts); !errors.Is(err, oracle.ErrUnsupportedIsolationLevel) {
+			t.Errorf("ErrUnsupportedIsolationLevel expected for %+v, got: %v", opts, err)
+		}
+	}
+}
+
+func TestReadOnlyTransaction(t *testing.T) {
+	db := getDb(t)
+
+	ctx := oracle.WithTransactionName(context.Background(), "read_only_test")
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var name string
+		if err := tx.Raw("SELECT NAME FROM V$TRANSACTION WHERE ADDR = (SELECT TADDR FROM V$SESSION WHERE SID = SYS_CONTEXT('USERENV', 'SID'))").Scan(&name).Error; err != nil {
+			return err
+		}
+		if name != "read_only_test" {
+			t.Errorf("unexpected transaction name: %s", name)
+		}
+
+		// ORA-01456: may not perform insert/delete/update operation inside a READ ONLY transaction
+		if err := tx.Where("1 = 0").Delete(&Customer{}).Error; err == nil {
+			t.Errorf("error expected in the read-only transaction")
+		}
+		return nil
+	}, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = db.Begin(&sql.TxOptions{Isolation: sql.LevelRepeatableRead}).Error; !errors.Is(err, oracle.ErrUnsupportedIsolationLevel) {
+		t.Errorf("ErrUnsupportedIsolationLevel expected, got: %v", err)
+	}
+}
+
+func TestNestedTransaction(t *testing.T) {
+	db, pool := getFakeDb(t)
+
+	errInner := errors.New("inner failed")
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Transaction(func(tx2 *gorm.DB) error {
+			return tx2.Transaction(func(tx3 *gorm.DB) error {
+				return errInner
+			})
+		}); !errors.Is(err, errInner) {
+			t.Errorf("inner error expected, got: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pool.begins != 1 || pool.commits != 1 || pool.rollbacks != 0 {
+		t.Errorf("unexpected transactions: %+v", pool)
+	}
+	if len(pool.execs) != 4 {
+		t.Fatalf("unexpected statements: %q", pool.execs)
+	}
+	for idx, prefix := range []string{"SAVEPOINT \"sp0x", "SAVEPOINT \"sp0x", "ROLLBACK TO SAVEPOINT \"sp0x", "ROLLBACK TO SAVEPOINT \"sp0x"} {
+		if !strings.HasPrefix(pool.execs[idx], prefix) {
+			t.Errorf("unexpected statement #%d: %s, expected: %s...", idx+1, pool.execs[idx], prefix)
+		}
+	}
+	// rolls back to the inner savepoint first
+	if pool.execs[2] != "ROLLBACK TO "+pool.execs[1] || pool.execs[3] != "ROLLBACK TO "+pool.execs[0] {
+		t.Errorf("unexpected statements: %q", pool.execs)
+	}
+}
+
+func TestSavePointName(t *testing.T) {
+	db, pool := getFakeDb(t)
+
+	tx := db.Begin()
+	for _, name := range []string{"before_update", `"Before Update"`, "a savepoint named by users; DROP TABLE users"} {
+		if err := tx.SavePoint(name).Error; err != nil {
+			t.Errorf("unexpected error of %q: %v", name, err)
+		}
+	}
+	expected := []string{
+		`SAVEPOINT "before_update"`,
+		`SAVEPOINT "Before Update"`,
+		`SAVEPOINT "a savepoint named by _4B22639B"`,
+	}
+	if !reflect.DeepEqual(pool.execs, expected) {
+		t.Errorf("unexpected statements: %q, expected: %q", pool.execs, expected)
+	}
+
+	// nothing is executed to release the savepoint, but the name is validated
+	dialector := db.Dialector.(*oracle.Dialector)
+	if err := dialector.ReleaseSavePoint(tx, "before_update"); err != nil || len(pool.execs) != len(expected) {
+		t.Errorf("unexpected release: %v, statements: %q", err, pool.execs)
+	}
+
+	for _, name := range []string{"", `""`, `sp"; DROP TABLE users; --`} {
+		if err := db.Begin().SavePoint(name).Error; err == nil {
+			t.Errorf("error expected for the invalid name %q", name)
+		}
+		if err := dialector.ReleaseSavePoint(tx, name); err == nil {
+			t.Errorf("error expected for releasing the invalid name %q", name)
+		}
+	}
+}
+
+func TestNestedTransactionSQL(t *testing.T) {
+	fakeDb, _ := getFakeDb(t)
+	recorder := &sqlRecorder{Interface: logger.Discard}
+	db := fakeDb.Session(&gorm.Session{DryRun: true, Logger: recorder})
+
+	errInner := errors.New("inner failed")
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Transaction(func(tx2 *gorm.DB) error {
+			return errInner
+		}); !errors.Is(err, errInner) {
+			t.Errorf("inner error expected, got: %v", err)
+		}
+		return tx.Transaction(func(tx2 *gorm.DB) error {
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the savepoints are named by gorm as sp%p, nothing is executed to release them
+	savePoint := regexp.MustCompile(`^SAVEPOINT "sp0x[0-9a-f]+"$`)
+	if len(recorder.sqls) != 3 || !savePoint.MatchString(recorder.sqls[0]) || !savePoint.MatchString(recorder.sqls[2]) ||
+		recorder.sqls[1] != "ROLLBACK TO "+recorder.sqls[0] {
+		t.Errorf("unexpected statements: %q", recorder.sqls)
+	}
+}
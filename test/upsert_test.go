@@ -0,0 +1,66 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+func TestUpsertSQL(t *testing.T) {
+	db := getDryRunDb(t, version11g)
+
+	c := getCustomerWithPrimaryKey("TestUpsertSQL")
+	c.CustomerID = 1
+	tx := checkTxError(t, db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "CUSTOMER_ID"}},
+		DoUpdates: clause.AssignmentColumns([]string{"CUSTOMER_NAME", "AGE"}),
+		Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "Customers.AGE < excluded.AGE"}}},
+	}).Create(&c))
+
+	expected := "MERGE INTO Customers USING (" +
+		"SELECT :p1 AS CUSTOMER_NAME,:p2 AS ADDRESS,:p3 AS CITY,:p4 AS STATE,:p5 AS ZIP_CODE,:p6 AS CREATED_TIME,:p7 AS AGE,:p8 AS CUSTOMER_ID FROM DUAL" +
+		") excluded ON (Customers.CUSTOMER_ID=excluded.CUSTOMER_ID)" +
+		" WHEN MATCHED THEN UPDATE SET CUSTOMER_NAME=excluded.CUSTOMER_NAME,AGE=excluded.AGE WHERE Customers.AGE < excluded.AGE" +
+		" WHEN NOT MATCHED THEN INSERT (CUSTOMER_NAME,ADDRESS,CITY,STATE,ZIP_CODE,CREATED_TIME,AGE,CUSTOMER_ID)" +
+		" VALUES (excluded.CUSTOMER_NAME,excluded.ADDRESS,excluded.CITY,excluded.STATE,excluded.ZIP_CODE,excluded.CREATED_TIME,excluded.AGE,CUSTOMERS_S.NEXTVAL)"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+	if len(tx.Statement.Vars) != 8 {
+		t.Errorf("%d vars bound, 8 expected", len(tx.Statement.Vars))
+	}
+}
+
+func TestUpsertDoNothingSQL(t *testing.T) {
+	db := getDryRunDb(t, version11g)
+
+	cs := []CustomerWithPrimaryKey{getCustomerWithPrimaryKey("TestUpsertDoNothingSQL"), getCustomerWithPrimaryKey("TestUpsertDoNothingSQL")}
+	tx := checkTxError(t, db.Clauses(clause.OnConflict{DoNothing: true}).Create(&cs))
+
+	expected := "MERGE INTO Customers USING (" +
+		"SELECT :p1 AS CUSTOMER_NAME,:p2 AS ADDRESS,:p3 AS CITY,:p4 AS STATE,:p5 AS ZIP_CODE,:p6 AS CREATED_TIME,:p7 AS AGE,NULL AS CUSTOMER_ID FROM DUAL" +
+		" UNION ALL SELECT :p8 AS CUSTOMER_NAME,:p9 AS ADDRESS,:p10 AS CITY,:p11 AS STATE,:p12 AS ZIP_CODE,:p13 AS CREATED_TIME,:p14 AS AGE,NULL AS CUSTOMER_ID FROM DUAL" +
+		") excluded ON (Customers.CUSTOMER_ID=excluded.CUSTOMER_ID)" +
+		" WHEN NOT MATCHED THEN INSERT (CUSTOMER_NAME,ADDRESS,CITY,STATE,ZIP_CODE,CREATED_TIME,AGE,CUSTOMER_ID)" +
+		" VALUES (excluded.CUSTOMER_NAME,excluded.ADDRESS,excluded.CITY,excluded.STATE,excluded.ZIP_CODE,excluded.CREATED_TIME,excluded.AGE,CUSTOMERS_S.NEXTVAL)"
+	if sql := tx.Statement.SQL.String(); sql != expected {
+		t.Errorf("unexpected SQL:\n%s\nexpected:\n%s", sql, expected)
+	}
+}
+
+func TestUpsertModels(t *testing.T) {
+	count := 4
+	batchId := uuid.NewString()
+	cs := make([]CustomerWithPrimaryKey, count)
+	for i := 0; i < count; i++ {
+		cs[i] = getCustomerWithPrimaryKey(fmt.Sprintf("TestUpsertModels:batch-%s:", batchId))
+	}
+
+	db := getDb(t)
+	tx := checkTxError(t, db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&cs))
+	if tx.RowsAffected != int64(count) {
+		t.Errorf("upsert %d rows affected, %d expected", tx.RowsAffected, count)
+	}
+}
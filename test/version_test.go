@@ -0,0 +1,75 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	oracle "github.com/uonun/gorm-oracle"
+	"gorm.io/gorm"
+)
+
+func TestParseVersion(t *testing.T) {
+	for banner, expected := range map[string]oracle.Version{
+		version11g: {Major: 11, Minor: 2},
+		version19c: {Major: 19},
+		"Oracle Database 12c Enterprise Edition Release 12.2.0.1.0 - 64bit Production":                         {Major: 12, Minor: 2},
+		"Oracle Database 19c Enterprise Edition Release 19.0.0.0.0 - Production Version 19.3.0.0.0":            {Major: 19, Minor: 3},
+		"Oracle Database 23ai Free Release 23.0.0.0.0 - Develop, Learn, and Run for Free Version 23.4.0.24.05": {Major: 23, Minor: 4},
+		"Oracle Database 18c Express Edition Release 18.0.0.0.0 - Production":                                  {Major: 18},
+		"Oracle Database 26ai": {Major: 26},
+		"12.1.0.2":             {Major: 12, Minor: 1},
+	} {
+		v, err := oracle.ParseVersion(banner)
+		if err != nil || v != expected {
+			t.Errorf("unexpected version of %q: %v, %v, expected: %v", banner, v, err, expected)
+		}
+	}
+
+	if _, err := oracle.ParseVersion("unknown"); err == nil {
+		t.Errorf("error expected for unknown version")
+	}
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	for version, expected := range map[oracle.Version]oracle.Capabilities{
+		{Major: 11, Minor: 2}: {},
+		{Major: 12, Minor: 1}: {Identity: true, OffsetFetch: true},
+		{Major: 12, Minor: 2}: {Identity: true, OffsetFetch: true, LongIdentifier: true},
+		{Major: 21}:           {Identity: true, OffsetFetch: true, LongIdentifier: true, JSONType: true, SequenceRestart: true},
+		{Major: 23, Minor: 4}: {Identity: true, OffsetFetch: true, LongIdentifier: true, JSONType: true, SequenceRestart: true, NativeBoolean: true, IfExistsDDL: true, Vector: true},
+	} {
+		if c := oracle.CapabilitiesOf(version); c != expected {
+			t.Errorf("unexpected capabilities of %v: %+v, expected: %+v", version, c, expected)
+		}
+	}
+}
+
+func TestCapabilitiesOverrideSQL(t *testing.T) {
+	db := getDryRunDbWithConfig(t, oracle.Config{ServerVersion: version19c, Capabilities: &oracle.Capabilities{}})
+
+	var rows []CustomerWithPrimaryKey
+	tx := checkTxError(t, db.Limit(10).Find(&rows))
+
+	if sql := tx.Statement.SQL.String(); !strings.Contains(sql, "ROWNUM") {
+		t.Errorf("LIMIT is expected to be rewritten by ROWNUM:\n%s", sql)
+	}
+}
+
+func TestDialectorByValue(t *testing.T) {
+	db, err := gorm.Open(oracle.Dialector{Config: &oracle.Config{DSN: dsn, ServerVersion: version19c, SkipInitializeWithVersion: true}},
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("gorm.Open Error %s", err)
+	}
+
+	dialector := db.Dialector.(oracle.Dialector)
+	if version := dialector.Version(); !version.AtLeast(19, 0) {
+		t.Errorf("unexpected version: %v", version)
+	}
+	if capabilities := dialector.ServerCapabilities(); capabilities != oracle.CapabilitiesOf(dialector.Version()) {
+		t.Errorf("unexpected capabilities: %+v", capabilities)
+	}
+	if _, ok := db.Migrator().(oracle.Migrator); !ok {
+		t.Errorf("unexpected migrator: %T", db.Migrator())
+	}
+}
@@ -0,0 +1,224 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 2 * time.Second
+
+	ctxKeyTransactionName string = "transaction_name"
+
+	// transactionNameMaxLength is the max length in bytes of the name of SET TRANSACTION
+	transactionNameMaxLength = 255
+)
+
+// ErrUnsupportedIsolationLevel is returned when beginning a transaction with an isolation level
+// not supported by Oracle, only sql.LevelReadCommitted and sql.LevelSerializable are supported.
+var ErrUnsupportedIsolationLevel = errors.New("isolation level not supported")
+
+// WithTransactionName returns a copy of ctx carrying the name of the transactions begun with it,
+// which is set by SET TRANSACTION NAME and shown in v$transaction, e.g.:
+//
+//	db.WithContext(oracle.WithTransactionName(ctx, "close_invoice")).Transaction(func(tx *gorm.DB) error { ... })
+func WithTransactionName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, ctxKeyTransactionName, name)
+}
+
+// TransactionStatement returns the SET TRANSACTION statement executed as the first statement
+// of the transaction begun with opts and ctx, or an empty string if nothing is to be set:
+//
+//	sql.LevelSerializable => SET TRANSACTION ISOLATION LEVEL SERIALIZABLE
+//	ReadOnly              => SET TRANSACTION READ ONLY
+//	WithTransactionName   => SET TRANSACTION NAME 'close_invoice'
+//
+// A read-only transaction sees the data committed before it begins, so it can not be combined
+// with sql.LevelReadCommitted, other isolation levels are rejected by ErrUnsupportedIsolationLevel.
+func TransactionStatement(ctx context.Context, opts *sql.TxOptions) (string, error) {
+	var settings []string
+	if opts != nil {
+		switch opts.Isolation {
+		case sql.LevelDefault:
+		case sql.LevelReadCommitted:
+			if opts.ReadOnly {
+				return "", fmt.Errorf("%w: read-only transaction with %s, use %s instead", ErrUnsupportedIsolationLevel, opts.Isolation, sql.LevelSerializable)
+			}
+			settings = append(settings, "ISOLATION LEVEL READ COMMITTED")
+		case sql.LevelSerializable:
+			if !opts.ReadOnly {
+				settings = append(settings, "ISOLATION LEVEL SERIALIZABLE")
+			}
+		default:
+			return "", fmt.Errorf("%w: %s, use %s or %s instead", ErrUnsupportedIsolationLevel, opts.Isolation, sql.LevelReadCommitted, sql.LevelSerializable)
+		}
+
+		// the read-only transaction is serializable
+		if opts.ReadOnly {
+			settings = append(settings, "READ ONLY")
+		}
+	}
+
+	if name, ok := ctx.Value(ctxKeyTransactionName).(string); ok && name != "" {
+		if len(name) > transactionNameMaxLength {
+			return "", fmt.Errorf("transaction name %q is longer than %d bytes", name, transactionNameMaxLength)
+		}
+		settings = append(settings, "NAME "+quoteString(name))
+	}
+
+	if len(settings) == 0 {
+		return "", nil
+	}
+	return "SET TRANSACTION " + strings.Join(settings, " "), nil
+}
+
+// TransactionOptions are the options of Transaction
+type TransactionOptions struct {
+	// TxOptions is passed to db.Transaction
+	TxOptions *sql.TxOptions
+	// MaxAttempts is the max number of attempts including the first one, default: 3
+	MaxAttempts int
+	// Backoff returns the duration to wait before the next attempt, attempt starts from 1,
+	// default: ExponentialBackoff(100ms, 2s)
+	Backoff func(attempt int) time.Duration
+	// Retryable decides whether to retry by the error, default: Error.Retryable
+	Retryable func(err error) bool
+}
+
+// TransactionError is returned by Transaction when it fails after more than one attempt,
+// or the retries are stopped by the context, it unwraps to the error of the last attempt.
+type TransactionError struct {
+	// Attempts are the errors of each attempt
+	Attempts []error
+	// Canceled is the error of the context which stops the retries, e.g.: context.DeadlineExceeded
+	Canceled error
+}
+
+// Error implements error interface
+func (e *TransactionError) Error() string {
+	messages := make([]string, len(e.Attempts))
+	for i, err := range e.Attempts {
+		messages[i] = fmt.Sprintf("#%d: %v", i+1, err)
+	}
+	msg := fmt.Sprintf("transaction failed after %d attempts: %s", len(e.Attempts), strings.Join(messages, "; "))
+	if e.Canceled != nil {
+		msg += ", retries stopped: " + e.Canceled.Error()
+	}
+	return msg
+}
+
+// Is makes errors.Is to match the error of the context, e.g.: errors.Is(err, context.Canceled)
+func (e *TransactionError) Is(target error) bool {
+	return e.Canceled != nil && errors.Is(e.Canceled, target)
+}
+
+// Unwrap returns the error of the last attempt
+func (e *TransactionError) Unwrap() error {
+	if len(e.Attempts) == 0 {
+		return nil
+	}
+	return e.Attempts[len(e.Attempts)-1]
+}
+
+// ExponentialBackoff returns a backoff doubling from initial up to max
+func ExponentialBackoff(initial, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := initial
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		return d
+	}
+}
+
+// Transaction runs fn in a transaction by db.Transaction, and retries the whole transaction
+// on the transient failures, e.g.: deadlock (ORA-00060), serialization failure (ORA-08177),
+// resource busy (ORA-00054) and connection lost (ORA-03113):
+//
+//	err := oracle.Transaction(db, nil, func(tx *gorm.DB) error {
+//		...
+//	})
+//
+// The broken connections are discarded from the pool, so the next attempt runs on
+// another connection. fn must be safe to be called more than once.
+//
+// It does not retry if db is already in a transaction, since only the whole transaction
+// can be retried. Neither does it retry if Commit fails, e.g.: the connection is lost while
+// committing, since the transaction may have been committed, nor after the context is done.
+func Transaction(db *gorm.DB, opts *TransactionOptions, fn func(tx *gorm.DB) error) error {
+	var (
+		maxAttempts = defaultMaxAttempts
+		backoff     = ExponentialBackoff(defaultInitialBackoff, defaultMaxBackoff)
+		retryable   = isRetryable
+		txOptions   []*sql.TxOptions
+	)
+	if opts != nil {
+		if opts.MaxAttempts > 0 {
+			maxAttempts = opts.MaxAttempts
+		}
+		if opts.Backoff != nil {
+			backoff = opts.Backoff
+		}
+		if opts.Retryable != nil {
+			retryable = opts.Retryable
+		}
+		if opts.TxOptions != nil {
+			txOptions = append(txOptions, opts.TxOptions)
+		}
+	}
+
+	if committer, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok && committer != nil {
+		maxAttempts = 1
+	}
+
+	ctx := db.Statement.Context
+	attempts := make([]error, 0, 1)
+	for attempt := 1; ; attempt++ {
+		// committing is true if fn succeeds, the error returned then is raised by Commit
+		var committing bool
+		err := db.Transaction(func(tx *gorm.DB) error {
+			err := fn(tx)
+			committing = err == nil
+			return err
+		}, txOptions...)
+		if err == nil {
+			return nil
+		}
+
+		// the errors of Commit and Rollback are not translated by the callbacks
+		err = translateError(err)
+		attempts = append(attempts, err)
+		if attempt >= maxAttempts || committing || ctx.Err() != nil || !retryable(err) {
+			break
+		}
+
+		timer := time.NewTimer(backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &TransactionError{Attempts: attempts, Canceled: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+
+	if len(attempts) == 1 {
+		return attempts[0]
+	}
+	return &TransactionError{Attempts: attempts}
+}
+
+func isRetryable(err error) bool {
+	e, ok := AsError(err)
+	return ok && e.Retryable()
+}
@@ -0,0 +1,122 @@
+package oracle
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is the numeric version of Oracle Database, e.g.: 19.3.0
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+var (
+	// Version 19.3.0.0.0, which is the last line of BANNER_FULL since 18c
+	versionFullPattern = regexp.MustCompile(`(?i)\bVersion\s+(\d+(?:\.\d+)*)`)
+	// Release 11.2.0.4.0
+	versionReleasePattern = regexp.MustCompile(`(?i)\bRelease\s+(\d+(?:\.\d+)*)`)
+	// 19.3.0.0.0
+	versionNumberPattern = regexp.MustCompile(`\b(\d+(?:\.\d+)+)\b`)
+	// 11g, 12c, 23ai
+	versionNamePattern = regexp.MustCompile(`(?i)\b(\d+)(?:g|c|ai)\b`)
+)
+
+// ParseVersion parses the version from the banner of v$version or the version string, e.g.:
+//
+//	Oracle Database 11g Enterprise Edition Release 11.2.0.4.0 - 64bit Production
+//	Oracle Database 19c Enterprise Edition Release 19.0.0.0.0 - Production Version 19.3.0.0.0
+//	Oracle Database 23ai Free Release 23.0.0.0.0 - Develop, Learn, and Run for Free
+//	12.2.0.1
+func ParseVersion(s string) (Version, error) {
+	for _, pattern := range []*regexp.Regexp{versionFullPattern, versionReleasePattern, versionNumberPattern} {
+		if matches := pattern.FindStringSubmatch(s); len(matches) == 2 {
+			return parseVersionNumber(matches[1])
+		}
+	}
+
+	if matches := versionNamePattern.FindStringSubmatch(s); len(matches) == 2 {
+		major, _ := strconv.Atoi(matches[1])
+		return Version{Major: major}, nil
+	}
+
+	return Version{}, fmt.Errorf("unrecognized version %q", s)
+}
+
+func parseVersionNumber(s string) (Version, error) {
+	var (
+		v     Version
+		parts = strings.Split(s, ".")
+		nums  = []*int{&v.Major, &v.Minor, &v.Patch}
+	)
+	for i := 0; i < len(parts) && i < len(nums); i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return Version{}, fmt.Errorf("unrecognized version %q", s)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+// AtLeast returns true if the version is not lower than major.minor
+func (v Version) AtLeast(major, minor int) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+// Less returns true if the version is lower than other
+func (v Version) Less(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+// String implements fmt.Stringer interface
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Capabilities are the features supported by the server, which decide how the SQL is built.
+type Capabilities struct {
+	// Identity is true if IDENTITY columns are supported, since 12.1
+	// See: https://docs.oracle.com/database/121/DRDAA/migr_tools_feat.htm#DRDAA109
+	Identity bool
+	// OffsetFetch is true if OFFSET ... FETCH ... is supported, since 12.1,
+	// otherwise LIMIT is rewritten by ROWNUM
+	// See: https://docs.oracle.com/database/121/SQLRF/statements_10002.htm#SQLRF55636
+	OffsetFetch bool
+	// LongIdentifier is true if the identifiers are up to 128 bytes, since 12.2, otherwise 30 bytes
+	// See: https://docs.oracle.com/en/database/oracle/oracle-database/12.2/newft/new-features.html#GUID-64283AD6-0939-47B0-856E-5E9255D7246B
+	LongIdentifier bool
+	// JSONType is true if the native JSON data type is supported, since 21
+	JSONType bool
+	// SequenceRestart is true if ALTER SEQUENCE ... RESTART is supported, since 18
+	SequenceRestart bool
+	// NativeBoolean is true if the BOOLEAN data type is supported in SQL, since 23
+	NativeBoolean bool
+	// IfExistsDDL is true if `IF [NOT] EXISTS` is supported in DDL, since 23
+	IfExistsDDL bool
+	// Vector is true if the VECTOR data type is supported, since 23.4
+	Vector bool
+}
+
+// CapabilitiesOf returns the capabilities of the version
+func CapabilitiesOf(v Version) Capabilities {
+	return Capabilities{
+		Identity:        v.AtLeast(12, 1),
+		OffsetFetch:     v.AtLeast(12, 1),
+		LongIdentifier:  v.AtLeast(12, 2),
+		JSONType:        v.AtLeast(21, 0),
+		SequenceRestart: v.AtLeast(18, 0),
+		NativeBoolean:   v.AtLeast(23, 0),
+		IfExistsDDL:     v.AtLeast(23, 0),
+		Vector:          v.AtLeast(23, 4),
+	}
+}